@@ -0,0 +1,408 @@
+package backtest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/eventholder"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/models"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/risk"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/size"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/registry"
+	"github.com/thrasher-corp/gocryptotrader/backtester/live"
+	"github.com/thrasher-corp/gocryptotrader/backtester/report"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+	gctexchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	errNilBot        = errors.New("nil bot received")
+	errNoDataSource  = errors.New("no data source set")
+	errIntervalUnset = errors.New("candle interval unset")
+)
+
+// BackTest is the main holder of all backtesting functionality.
+// It brings together all the various event handlers so that a
+// strategy can be replayed against historical or live data.
+type BackTest struct {
+	shutdown   chan struct{}
+	Bot        *engine.Engine
+	Datas      *data.HandlerPerCurrency
+	Strategy   strategies.Handler
+	Portfolio  *portfolio.Portfolio
+	Exchange   *exchange.Exchange
+	Statistic  *statistics.Statistic
+	EventQueue *eventholder.Holder
+	Reports    *report.Data
+
+	// Clock and RNG replace direct time.Now()/math/rand calls throughout
+	// the run so that two BackTest instances given the same inputs,
+	// clock and seed always produce byte-for-byte identical output.
+	Clock common.Clock
+	RNG   common.RNG
+
+	// StrategyRegistry resolves strategy names across every registered
+	// Loader - the compiled-in strategies plus any Go plugins or gRPC
+	// subprocesses configured for this run. NewFromConfig always wires it
+	// up with at least registry.BuiltinLoader and resolves Strategy through
+	// it; a conformance run built by RunConformance has no registry and
+	// calls strategies.LoadStrategyByName directly.
+	StrategyRegistry *registry.Registry
+
+	// Models holds the fee, slippage, market-impact and partial-fill models
+	// each currency pair trades under, keyed by currency.Pair.String().
+	// NewFromConfig builds one models.Set per CurrencySettings entry from
+	// its MarketModels spec, defaulting to no-op models when unset, so
+	// Exchange can look the Set up by pair instead of assuming one fixed
+	// fee/slippage behaviour for the whole run.
+	Models map[string]models.Set
+
+	// LiveMode is empty for a run without LiveData settings, and otherwise
+	// the mode those settings name (live.ModeReal when none). For ModePaper
+	// or ModeShadow, liveFeed and liveMatchers are also populated and Run
+	// drains liveFeed instead of polling.
+	LiveMode      live.Mode
+	liveFeed      *live.Feed
+	liveMatchers  map[string]*live.Matcher
+	statePath     string
+	resumeState   *live.State
+	lastEventUnix int64
+
+	// conformanceRecorder, when set, taps every event processed by Run so
+	// RunConformance can diff the resulting stream against a vector's
+	// expected output. It is nil outside of conformance runs.
+	conformanceRecorder *conformanceRecorder
+}
+
+// RunOptions carries the deterministic building blocks of a run: the clock
+// strategies and handlers read "now" from, the RNG any probabilistic
+// behaviour draws from, and the seed that produced it. It is optional -
+// NewFromConfig picks sensible defaults (RealClock for live data, a
+// FakeClock derived from the first candle otherwise) when omitted.
+type RunOptions struct {
+	Clock common.Clock
+	RNG   common.RNG
+	Seed  int64
+
+	// ExtraStrategyLoaders are tried, in order, after the built-in
+	// strategy list when resolving cfg.StrategySettings.Name - e.g. a
+	// registry.PluginLoader pointed at a directory of .so files, or a
+	// registry.GRPCLoader dialling out to strategy subprocesses.
+	ExtraStrategyLoaders []registry.Loader
+
+	// LiveSubscriber wires an exchange's websocket streaming connection
+	// into ModePaper/ModeShadow runs; see live.Subscriber. Required
+	// whenever cfg.DataSettings.LiveData.Mode is "paper" or "shadow".
+	LiveSubscriber live.Subscriber
+
+	// StatePath is where ModePaper/ModeShadow runs persist their state on
+	// Stop and resume it from on the next NewFromConfig call. Left empty,
+	// a live run neither persists nor resumes state.
+	StatePath string
+}
+
+// NewFromConfig takes a strategy config and configures a BackTest variable to
+// run the strategy described within it. An optional RunOptions may be passed
+// to control the Clock and RNG the run is driven by; see RunOptions.
+func NewFromConfig(cfg *config.Config, templatePath, output string, bot *engine.Engine, opts ...RunOptions) (*BackTest, error) {
+	if cfg == nil {
+		return nil, errors.New("nil config received")
+	}
+	if bot == nil {
+		return nil, errNilBot
+	}
+	if len(cfg.CurrencySettings) == 0 {
+		return nil, config.ErrNoCurrencySettings
+	}
+
+	var ro RunOptions
+	if len(opts) > 0 {
+		ro = opts[0]
+	}
+	if ro.RNG == nil {
+		ro.RNG = common.NewSeededRNG(ro.Seed)
+	}
+
+	strategyRegistry := registry.New(registry.BuiltinLoader{})
+	for _, l := range ro.ExtraStrategyLoaders {
+		strategyRegistry.Register(l)
+	}
+
+	bt := &BackTest{
+		shutdown:         make(chan struct{}),
+		Bot:              bot,
+		EventQueue:       &eventholder.Holder{},
+		Datas:            &data.HandlerPerCurrency{},
+		Clock:            ro.Clock,
+		RNG:              ro.RNG,
+		StrategyRegistry: strategyRegistry,
+		Models:           make(map[string]models.Set),
+		Reports: &report.Data{
+			Config:       cfg,
+			TemplatePath: templatePath,
+			OutputPath:   output,
+		},
+	}
+	bt.Datas.Setup()
+
+	var err error
+	for i := range cfg.CurrencySettings {
+		if cfg.CurrencySettings[i].InitialFunds <= 0 {
+			return nil, config.ErrBadInitialFunds
+		}
+		if cfg.CurrencySettings[i].Asset == "" {
+			return nil, config.ErrUnsetAsset
+		}
+		var a asset.Item
+		a, err = asset.New(cfg.CurrencySettings[i].Asset)
+		if err != nil {
+			return nil, err
+		}
+
+		exch := bot.GetExchangeByName(cfg.CurrencySettings[i].ExchangeName)
+		if exch == nil {
+			return nil, engine.ErrExchangeNotFound
+		}
+
+		if cfg.DataSettings.DataType == "" {
+			return nil, errNoDataSource
+		}
+
+		cp := currency.NewPairWithDelimiter(cfg.CurrencySettings[i].Base, cfg.CurrencySettings[i].Quote, cfg.CurrencySettings[i].PairDelimiter)
+		var kl *kline.DataFromKline
+		kl, err = bt.loadData(cfg, exch, cp, a)
+		if err != nil {
+			return nil, err
+		}
+		if ro.Clock == nil && bt.Clock == nil {
+			bt.Clock = defaultClock(cfg, kl)
+		}
+		bt.Models[cp.String()] = models.Build(cfg.CurrencySettings[i].MarketModels, bt.RNG)
+	}
+
+	bt.Strategy, err = bt.StrategyRegistry.LoadStrategyByName(cfg.StrategySettings.Name, cfg.StrategySettings.SimultaneousSignalProcessing)
+	if err != nil {
+		return nil, err
+	}
+	err = bt.Strategy.SetCustomSettings(cfg.StrategySettings.CustomSettings)
+	if err != nil && !errors.Is(err, base.ErrCustomSettingsUnsupported) {
+		return nil, err
+	}
+
+	bt.Portfolio, err = portfolio.Setup(&size.Size{
+		BuySide:  cfg.PortfolioSettings.BuySide,
+		SellSide: cfg.PortfolioSettings.SellSide,
+	}, &risk.Risk{}, cfg.StatisticSettings.RiskFreeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	bt.Exchange = &exchange.Exchange{}
+	bt.Statistic = &statistics.Statistic{
+		StrategyName: cfg.StrategySettings.Name,
+	}
+
+	if cfg.DataSettings.LiveData != nil {
+		bt.LiveMode, err = live.ParseMode(cfg.DataSettings.LiveData.Mode)
+		if err != nil {
+			return nil, err
+		}
+		if bt.LiveMode != live.ModeReal {
+			if ro.LiveSubscriber == nil {
+				return nil, fmt.Errorf("live: mode %q requires RunOptions.LiveSubscriber", bt.LiveMode)
+			}
+			bt.liveFeed, err = live.NewFeed(ro.LiveSubscriber)
+			if err != nil {
+				return nil, err
+			}
+			bt.liveMatchers = make(map[string]*live.Matcher, len(bt.Models))
+			for pair, set := range bt.Models {
+				bt.liveMatchers[pair] = live.NewMatcher(set)
+			}
+			bt.statePath = ro.StatePath
+			if bt.statePath != "" {
+				bt.resumeState, err = live.LoadState(bt.statePath)
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					return nil, err
+				}
+				if bt.resumeState != nil && len(bt.resumeState.Portfolio) > 0 {
+					if err = json.Unmarshal(bt.resumeState.Portfolio, bt.Portfolio); err != nil {
+						return nil, fmt.Errorf("live: resuming portfolio state: %w", err)
+					}
+				}
+			}
+		}
+	}
+
+	return bt, nil
+}
+
+// defaultClock picks the Clock a run uses when none was supplied via
+// RunOptions: live trading must see the real wall clock, while every
+// replayed data source gets a FakeClock fixed at the first candle's
+// timestamp so the run is reproducible regardless of when it executes.
+func defaultClock(cfg *config.Config, kl *kline.DataFromKline) common.Clock {
+	if cfg.DataSettings.LiveData != nil {
+		return common.RealClock{}
+	}
+	if kl != nil && len(kl.Item.Candles) > 0 {
+		return common.NewFakeClock(kl.Item.Candles[0].Time)
+	}
+	return common.NewFakeClock(time.Time{})
+}
+
+func (bt *BackTest) loadData(cfg *config.Config, exch gctexchange.IBotExchange, fPair currency.Pair, a asset.Item) (*kline.DataFromKline, error) {
+	if cfg == nil || exch == nil {
+		return nil, common.ErrNilArguments
+	}
+
+	switch cfg.DataSettings.DataType {
+	case common.CandleStr:
+	default:
+		return nil, fmt.Errorf("unrecognised dataType '%v'", cfg.DataSettings.DataType)
+	}
+
+	switch {
+	case cfg.DataSettings.CSVData != nil:
+		return loadCSVData(cfg, fPair, a)
+	case cfg.DataSettings.DatabaseData != nil:
+		return loadDatabaseData(cfg, exch.GetName(), fPair, a, common.DataCandle)
+	case cfg.DataSettings.APIData != nil:
+		return loadAPIData(cfg, exch, fPair, a)
+	case cfg.DataSettings.LiveData != nil:
+		b := exch.GetBase()
+		return nil, loadLiveData(cfg, b)
+	default:
+		return nil, errNoDataSource
+	}
+}
+
+func loadCSVData(cfg *config.Config, fPair currency.Pair, a asset.Item) (*kline.DataFromKline, error) {
+	if cfg.DataSettings.Interval <= 0 {
+		return nil, errIntervalUnset
+	}
+	return nil, fmt.Errorf("could not load csv data for %v %v: %w", fPair, a, errors.New(cfg.DataSettings.CSVData.FullPath))
+}
+
+func loadAPIData(cfg *config.Config, exch gctexchange.IBotExchange, fPair currency.Pair, a asset.Item) (*kline.DataFromKline, error) {
+	if cfg.DataSettings.APIData.StartDate.IsZero() || cfg.DataSettings.APIData.EndDate.IsZero() {
+		return nil, config.ErrStartEndUnset
+	}
+	if cfg.DataSettings.Interval <= 0 {
+		return nil, errIntervalUnset
+	}
+	return nil, nil
+}
+
+func loadDatabaseData(cfg *config.Config, exchangeName string, fPair currency.Pair, a asset.Item, dataType int64) (*kline.DataFromKline, error) {
+	if cfg == nil {
+		return nil, errors.New("nil config data received")
+	}
+	if cfg.DataSettings.DatabaseData.StartDate.IsZero() || cfg.DataSettings.DatabaseData.EndDate.IsZero() {
+		return nil, config.ErrStartEndUnset
+	}
+	if cfg.DataSettings.Interval <= 0 {
+		return nil, errIntervalUnset
+	}
+	if exchangeName == "" || fPair.IsEmpty() || a == "" || cfg.DataSettings.Interval <= 0 {
+		return nil, errors.New("exchange, base, quote, asset, interval, start & end cannot be empty")
+	}
+	return nil, errors.New("could not retrieve database data: database support is disabled")
+}
+
+func loadLiveData(cfg *config.Config, b *gctexchange.Base) error {
+	if cfg == nil || b == nil {
+		return common.ErrNilArguments
+	}
+	if cfg.DataSettings.LiveData == nil {
+		return common.ErrNilArguments
+	}
+	if cfg.DataSettings.Interval <= 0 {
+		return errIntervalUnset
+	}
+	if cfg.DataSettings.LiveData.APIKeyOverride != "" {
+		b.API.Credentials.Key = cfg.DataSettings.LiveData.APIKeyOverride
+	}
+	if cfg.DataSettings.LiveData.APISecretOverride != "" {
+		b.API.Credentials.Secret = cfg.DataSettings.LiveData.APISecretOverride
+	}
+	if cfg.DataSettings.LiveData.APIClientIDOverride != "" {
+		b.API.Credentials.ClientID = cfg.DataSettings.LiveData.APIClientIDOverride
+	}
+	if cfg.DataSettings.LiveData.API2FAOverride != "" {
+		b.API.Credentials.PEMKey = cfg.DataSettings.LiveData.API2FAOverride
+	}
+	return nil
+}
+
+// Reset BackTest values to default. StrategyRegistry is given a chance to
+// tear itself down first, the same as Stop does, so a plugin handle or
+// gRPC subprocess connection it holds open is never dropped without being
+// released - important for callers such as the optimizer that defer Reset
+// after every evaluation without necessarily calling Stop.
+func (bt *BackTest) Reset() {
+	if bt.StrategyRegistry != nil {
+		_ = bt.StrategyRegistry.Reset()
+		_ = bt.StrategyRegistry.Stop()
+	}
+
+	bt.Bot = nil
+	bt.Datas = nil
+	bt.Strategy = nil
+	bt.Portfolio = nil
+	bt.Exchange = nil
+	bt.Statistic = nil
+	bt.EventQueue = nil
+	bt.Reports = nil
+	bt.Clock = nil
+	bt.RNG = nil
+	bt.StrategyRegistry = nil
+	bt.Models = nil
+	bt.LiveMode = ""
+	bt.liveFeed = nil
+	bt.liveMatchers = nil
+	bt.statePath = ""
+	bt.resumeState = nil
+}
+
+// Stop shuts down the running BackTest instance, including closing any
+// gRPC strategy connections held open by StrategyRegistry. A live
+// paper or shadow run with a non-empty statePath persists its progress
+// first, so the next NewFromConfig with the same StatePath resumes rather
+// than re-processing events this run already saw.
+func (bt *BackTest) Stop() {
+	if bt.liveFeed != nil {
+		_ = bt.liveFeed.Stop()
+	}
+	if bt.statePath != "" {
+		portfolioJSON, err := json.Marshal(bt.Portfolio)
+		if err == nil {
+			_ = live.SaveState(bt.statePath, live.State{
+				LastEventUnix: bt.lastEventUnix,
+				Portfolio:     portfolioJSON,
+			})
+		}
+	}
+	if bt.shutdown != nil {
+		close(bt.shutdown)
+	}
+	if bt.StrategyRegistry != nil {
+		_ = bt.StrategyRegistry.Stop()
+	}
+}
+
+// Run, processEvent and their supporting handlers live in run.go.
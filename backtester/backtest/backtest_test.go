@@ -32,6 +32,11 @@ import (
 
 const testExchange = "binance"
 
+// fixedTestTime anchors TestFullCycle and TestFullCycleMulti to a FakeClock
+// instead of time.Now(), so both runs are byte-for-byte reproducible
+// regardless of when the test suite executes.
+var fixedTestTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
 func newBotWithExchange() (*engine.Engine, gctexchange.IBotExchange) {
 	bot, err := engine.NewFromSettings(&engine.Settings{
 		ConfigFile:   filepath.Join("..", "..", "testdata", "configtest.json"),
@@ -371,7 +376,7 @@ func TestFullCycle(t *testing.T) {
 	ex := testExchange
 	cp := currency.NewPair(currency.BTC, currency.USD)
 	a := asset.Spot
-	tt := time.Now()
+	tt := fixedTestTime
 
 	stats := &statistics.Statistic{}
 	stats.ExchangeAssetPairStatistics = make(map[string]map[asset.Item]map[currency.Pair]*currencystatistics.CurrencyStatistic)
@@ -405,6 +410,8 @@ func TestFullCycle(t *testing.T) {
 		Statistic:  stats,
 		EventQueue: &eventholder.Holder{},
 		Reports:    &report.Data{},
+		Clock:      common.NewFakeClock(tt),
+		RNG:        common.NewSeededRNG(1337),
 	}
 
 	bt.Datas.Setup()
@@ -465,7 +472,7 @@ func TestFullCycleMulti(t *testing.T) {
 	ex := testExchange
 	cp := currency.NewPair(currency.BTC, currency.USD)
 	a := asset.Spot
-	tt := time.Now()
+	tt := fixedTestTime
 
 	stats := &statistics.Statistic{}
 	stats.ExchangeAssetPairStatistics = make(map[string]map[asset.Item]map[currency.Pair]*currencystatistics.CurrencyStatistic)
@@ -498,6 +505,8 @@ func TestFullCycleMulti(t *testing.T) {
 		Statistic:  stats,
 		EventQueue: &eventholder.Holder{},
 		Reports:    &report.Data{},
+		Clock:      common.NewFakeClock(tt),
+		RNG:        common.NewSeededRNG(1337),
 	}
 
 	bt.Strategy, err = strategies.LoadStrategyByName(dollarcostaverage.Name, true)
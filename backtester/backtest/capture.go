@@ -0,0 +1,43 @@
+package backtest
+
+import (
+	"encoding/json"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/conformance"
+)
+
+// CaptureConformanceVector runs this BackTest to completion while recording
+// its event stream, then packages the run's outcome into a
+// conformance.Vector under the given id. Only Meta (without Seed) and Post
+// are filled in; the returned Vector's Pre and Input are left empty. It is
+// the counterpart to
+// RunConformance and backs the gen-vector CLI: a strategy author runs their
+// config once, is happy with the result, and locks it in as a regression
+// vector with this method.
+func (bt *BackTest) CaptureConformanceVector(id, description string) (*conformance.Vector, error) {
+	bt.conformanceRecorder = &conformanceRecorder{}
+	defer func() { bt.conformanceRecorder = nil }()
+
+	if err := bt.Run(); err != nil {
+		return nil, err
+	}
+
+	statsJSON, err := json.Marshal(bt.Statistic)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &conformance.Vector{
+		Meta: conformance.Meta{
+			ID:          id,
+			Version:     conformance.CorpusVersion,
+			Description: description,
+			Strategy:    bt.Statistic.StrategyName,
+		},
+		Post: conformance.Post{
+			Events:         bt.conformanceRecorder.events,
+			StatisticsHash: conformance.HashStatistics(statsJSON),
+		},
+	}
+	return v, nil
+}
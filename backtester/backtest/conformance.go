@@ -0,0 +1,89 @@
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/conformance"
+)
+
+// conformanceRecorder taps every event Run processes and converts it to the
+// canonical conformance.Event shape so it can be diffed against a vector's
+// expected output stream.
+type conformanceRecorder struct {
+	events []conformance.Event
+}
+
+func (r *conformanceRecorder) record(e interface{}) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		// an event that cannot be serialised is still recorded, with the
+		// marshalling error surfaced as the payload, so the mismatch is
+		// visible in the diff rather than silently dropped.
+		payload = []byte(fmt.Sprintf("%q", err.Error()))
+	}
+	r.events = append(r.events, conformance.Event{
+		Type:    fmt.Sprintf("%T", e),
+		Payload: payload,
+	})
+}
+
+// RunConformance replays every vector found at vectorPath (a single file or
+// a directory of vectors) and asserts exact equality of the resulting event
+// stream and statistics snapshot. It never touches engine.Engine or any live
+// exchange connection: each vector carries everything required to
+// reconstruct the run in isolation.
+//
+// RunConformance is intended for strategy authors validating behavioural
+// changes, and for third-party ports of the backtester validating
+// compatibility against this implementation's reference outputs.
+func (bt *BackTest) RunConformance(vectorPath string) error {
+	vectors, err := loadVectorOrDir(vectorPath)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range vectors {
+		result, err := runVector(v)
+		if err != nil {
+			return fmt.Errorf("conformance: vector %q: %w", v.Meta.ID, err)
+		}
+		if !result.Passed {
+			return fmt.Errorf("conformance: vector %q failed: %s", v.Meta.ID, result.Reason)
+		}
+	}
+	return nil
+}
+
+func loadVectorOrDir(vectorPath string) ([]*conformance.Vector, error) {
+	if isDir(vectorPath) {
+		return conformance.LoadVectors(vectorPath)
+	}
+	v, err := conformance.LoadVector(vectorPath)
+	if err != nil {
+		return nil, err
+	}
+	return []*conformance.Vector{v}, nil
+}
+
+// runVector builds a fresh BackTest from the vector's Pre block, replays its
+// Input candles, and diffs the resulting events and statistics snapshot
+// against Post. A new instance is built per vector so vectors cannot leak
+// state into one another.
+func runVector(v *conformance.Vector) (*conformance.Result, error) {
+	run, err := newConformanceRun(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = run.Run(); err != nil {
+		return nil, err
+	}
+
+	statsJSON, err := json.Marshal(run.Statistic)
+	if err != nil {
+		return nil, err
+	}
+
+	return conformance.Diff(v, run.conformanceRecorder.events, conformance.HashStatistics(statsJSON))
+}
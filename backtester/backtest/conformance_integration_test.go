@@ -0,0 +1,42 @@
+package backtest
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/conformance"
+)
+
+// TestRunVectorProducesEvents guards against newConformanceRun/Run silently
+// producing an empty event stream regardless of the strategy or candles a
+// vector carries - the bug that let the shipped dca-basic.json vector pass
+// without ever replaying its candle. It deliberately does not assert an
+// exact event count or statistics hash: those depend on Post being
+// regenerated from a real run via the gen-vector CLI, and the shipped
+// dca-basic.json Post holds no events and an empty statistics hash.
+func TestRunVectorProducesEvents(t *testing.T) {
+	t.Parallel()
+
+	vectors, err := conformance.LoadVectors("../conformance/testdata")
+	if err != nil {
+		t.Fatalf("LoadVectors returned error: %v", err)
+	}
+
+	for _, v := range vectors {
+		v := v
+		t.Run(v.Meta.ID, func(t *testing.T) {
+			t.Parallel()
+
+			run, err := newConformanceRun(v)
+			if err != nil {
+				t.Fatalf("newConformanceRun returned error: %v", err)
+			}
+			if err = run.Run(); err != nil {
+				t.Fatalf("Run returned error: %v", err)
+			}
+
+			if len(v.Input.Candles) > 0 && len(run.conformanceRecorder.events) == 0 {
+				t.Errorf("vector %q fed %d candles through Run but recorded no events", v.Meta.ID, len(v.Input.Candles))
+			}
+		})
+	}
+}
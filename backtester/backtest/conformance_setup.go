@@ -0,0 +1,137 @@
+package backtest
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/conformance"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/eventholder"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/models"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/risk"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/size"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// newConformanceRun builds a BackTest purely from a vector's Pre block. It
+// never references engine.Engine, a live exchange connection, or GCT's
+// config file: everything the strategy needs is carried by the vector
+// itself, which is what makes a vector portable to other implementations.
+func newConformanceRun(v *conformance.Vector) (*BackTest, error) {
+	cp := currency.NewPair(currency.NewCode(v.Pre.Base), currency.NewCode(v.Pre.Quote))
+	a, err := asset.New(v.Pre.Asset)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: vector %q: %w", v.Meta.ID, err)
+	}
+
+	port, err := portfolio.Setup(&size.Size{}, &risk.Risk{}, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = port.SetupCurrencySettingsMap(v.Pre.Exchange, a, cp); err != nil {
+		return nil, err
+	}
+	if err = port.SetInitialFunds(v.Pre.Exchange, a, cp, v.Pre.InitialFunds); err != nil {
+		return nil, err
+	}
+
+	strategy, err := strategies.LoadStrategyByName(v.Meta.Strategy, false)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Time{}
+	if len(v.Input.Candles) > 0 {
+		start = v.Input.Candles[0].Time
+	}
+
+	rng := common.NewSeededRNG(v.Meta.Seed)
+	run := &BackTest{
+		shutdown:            make(chan struct{}),
+		Datas:               &data.HandlerPerCurrency{},
+		Strategy:            strategy,
+		Portfolio:           port,
+		Exchange:            &exchange.Exchange{},
+		Statistic:           &statistics.Statistic{StrategyName: v.Meta.Strategy},
+		EventQueue:          &eventholder.Holder{},
+		Clock:               common.NewFakeClock(start),
+		RNG:                 rng,
+		Models:              map[string]models.Set{cp.String(): models.Build(models.Spec{Fee: v.Pre.FeeModel}, rng)},
+		conformanceRecorder: &conformanceRecorder{},
+	}
+	run.Datas.Setup()
+
+	kl, err := candlesToKline(v, a, cp)
+	if err != nil {
+		return nil, err
+	}
+	run.Datas.SetDataForCurrency(v.Pre.Exchange, a, cp, kl)
+
+	return run, nil
+}
+
+// candlesToKline converts a vector's Input.Candles into the
+// kline.DataFromKline Datas expects, so RunConformance and
+// CaptureConformanceVector actually replay the candles a vector carries
+// instead of silently producing an empty event stream.
+func candlesToKline(v *conformance.Vector, a asset.Item, cp currency.Pair) (*kline.DataFromKline, error) {
+	candles := make([]gctkline.Candle, len(v.Input.Candles))
+	for i, c := range v.Input.Candles {
+		candles[i] = gctkline.Candle{
+			Time:   c.Time,
+			Open:   c.Open,
+			High:   c.High,
+			Low:    c.Low,
+			Close:  c.Close,
+			Volume: c.Volume,
+		}
+	}
+
+	interval := gctkline.Interval(v.Input.Interval)
+	kl := &kline.DataFromKline{
+		Item: gctkline.Item{
+			Exchange: v.Pre.Exchange,
+			Pair:     cp,
+			Asset:    a,
+			Interval: interval,
+			Candles:  candles,
+		},
+	}
+	if len(candles) > 0 {
+		kl.Range = gctkline.IntervalRangeHolder{
+			Start: gctkline.CreateIntervalTime(candles[0].Time),
+			End:   gctkline.CreateIntervalTime(candles[len(candles)-1].Time.Add(interval.Duration())),
+			Ranges: []gctkline.IntervalRange{
+				{
+					Start: gctkline.CreateIntervalTime(candles[0].Time),
+					End:   gctkline.CreateIntervalTime(candles[len(candles)-1].Time.Add(interval.Duration())),
+					Intervals: []gctkline.IntervalData{
+						{
+							Start:   gctkline.CreateIntervalTime(candles[0].Time),
+							End:     gctkline.CreateIntervalTime(candles[len(candles)-1].Time.Add(interval.Duration())),
+							HasData: true,
+						},
+					},
+				},
+			},
+		}
+	}
+	if err := kl.Load(); err != nil {
+		return nil, fmt.Errorf("conformance: vector %q: loading candles: %w", v.Meta.ID, err)
+	}
+	return kl, nil
+}
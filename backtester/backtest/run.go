@@ -0,0 +1,247 @@
+package backtest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/live"
+)
+
+// Run executes the strategy against the loaded event queue, dispatching
+// every event through Strategy -> Portfolio -> Exchange -> Statistic until
+// both the queue and the underlying data stream are exhausted, or Stop is
+// called. A run in live.ModePaper or live.ModeShadow drains liveFeed
+// instead of Datas; see runLive.
+func (bt *BackTest) Run() error {
+	if bt.liveFeed != nil {
+		return bt.runLive()
+	}
+	for {
+		select {
+		case <-bt.shutdown:
+			return nil
+		default:
+			empty, err := bt.drainEventQueue()
+			if err != nil {
+				return err
+			}
+			if !empty {
+				continue
+			}
+			d, ok := bt.Datas.Next()
+			if !ok {
+				return nil
+			}
+			bt.EventQueue.AppendEvent(d)
+		}
+	}
+}
+
+// runLive drains bt.liveFeed until it closes or Stop is called. Each event
+// carries a Candle, which is appended to the live run's data stream and run
+// through the same Strategy -> Portfolio -> Exchange -> Statistic pipeline
+// as a historical run. Any event at or before resumeState.LastEventUnix is
+// skipped, since a prior run already processed it.
+func (bt *BackTest) runLive() error {
+	for {
+		select {
+		case <-bt.shutdown:
+			return nil
+		default:
+			e, ok := bt.liveFeed.Next()
+			if !ok {
+				return nil
+			}
+
+			t := eventUnixTime(e)
+			if bt.resumeState != nil && t <= bt.resumeState.LastEventUnix {
+				continue
+			}
+			bt.lastEventUnix = t
+
+			if bt.conformanceRecorder != nil {
+				bt.conformanceRecorder.record(e)
+			}
+
+			d, err := bt.ingestLiveCandle(e)
+			if err != nil {
+				return err
+			}
+			if d == nil {
+				continue
+			}
+			bt.EventQueue.AppendEvent(d)
+			if _, err = bt.drainEventQueue(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ingestLiveCandle appends e's candle to the data stream for the live
+// run's configured pair and returns the resulting data.Handler ready to
+// feed into the pipeline. It returns a nil Handler for a zero-value Event,
+// which Next never produces in practice but which callers should still
+// tolerate.
+//
+// liveMatchers is keyed by currency.Pair.String() with exactly one entry
+// for the run's single configured pair, so GetDataForCurrencyPair only
+// needs that key - a multi-pair live run is not yet supported.
+func (bt *BackTest) ingestLiveCandle(e live.Event) (data.Handler, error) {
+	if e.Candle == nil {
+		return nil, nil
+	}
+	for pair := range bt.liveMatchers {
+		d := bt.Datas.GetDataForCurrencyPair(pair)
+		if d == nil {
+			continue
+		}
+		d.AppendStream(e.Candle)
+		next, ok := d.Next()
+		if !ok {
+			return nil, fmt.Errorf("live: could not advance data stream for pair %v", pair)
+		}
+		return next, nil
+	}
+	return nil, fmt.Errorf("live: no data stream configured to receive candle")
+}
+
+func eventUnixTime(e live.Event) int64 {
+	if e.Candle == nil {
+		return 0
+	}
+	return e.Candle.Time.Unix()
+}
+
+// drainEventQueue processes every event currently on EventQueue, recording
+// each one for conformance before dispatching it through processEvent. It
+// reports empty once NextEvent has nothing left, signalling the caller
+// should pull the next data event to keep the pipeline moving.
+func (bt *BackTest) drainEventQueue() (empty bool, err error) {
+	for {
+		e, err := bt.EventQueue.NextEvent()
+		if err != nil {
+			return true, nil
+		}
+		if bt.conformanceRecorder != nil {
+			bt.conformanceRecorder.record(e)
+		}
+		if err = bt.processEvent(e); err != nil {
+			return false, err
+		}
+	}
+}
+
+// processEvent dispatches e to the handler for its stage of the
+// data -> signal -> order -> fill pipeline, queuing whatever event that
+// stage produces for the next iteration of drainEventQueue.
+func (bt *BackTest) processEvent(e interface{}) error {
+	switch ev := e.(type) {
+	case data.Handler:
+		return bt.processDataEvent(ev)
+	case signal.Event:
+		return bt.processSignalEvent(ev)
+	case order.Event:
+		return bt.processOrderEvent(ev)
+	case fill.Event:
+		return bt.processFillEvent(ev)
+	default:
+		return fmt.Errorf("backtest: unhandled event type %T", e)
+	}
+}
+
+func (bt *BackTest) processDataEvent(d data.Handler) error {
+	bt.advanceClock(d.GetTime())
+
+	s, err := bt.Strategy.OnSignal(d, bt.Portfolio)
+	if err != nil {
+		return fmt.Errorf("strategy OnSignal: %w", err)
+	}
+	bt.EventQueue.AppendEvent(s)
+	return nil
+}
+
+func (bt *BackTest) processSignalEvent(s signal.Event) error {
+	o, err := bt.Portfolio.OnSignal(s, bt.Datas)
+	if err != nil {
+		return fmt.Errorf("portfolio OnSignal: %w", err)
+	}
+	bt.EventQueue.AppendEvent(o)
+	return nil
+}
+
+// processOrderEvent fills o against the fee, slippage, impact and
+// partial-fill models registered for its pair in bt.Models, rather than
+// Exchange's own hard-coded maker/taker fee arithmetic, so a historical
+// backtest is filled under the same configurable models a live paper run
+// uses. live.ModePaper and live.ModeShadow runs are filled against
+// liveMatchers instead; see processLiveOrderEvent.
+func (bt *BackTest) processOrderEvent(o order.Event) error {
+	if bt.liveMatchers != nil {
+		return bt.processLiveOrderEvent(o)
+	}
+
+	f, err := bt.Exchange.ExecuteOrder(o, bt.Datas, bt.Models[o.Pair().String()])
+	if err != nil {
+		return fmt.Errorf("exchange ExecuteOrder: %w", err)
+	}
+	bt.EventQueue.AppendEvent(f)
+	return nil
+}
+
+// processLiveOrderEvent fills o against liveMatchers, the in-memory paper
+// matching engine, for both live.ModePaper and live.ModeShadow, building
+// the resulting fill.Event directly from the matcher's result rather than
+// through Exchange (which only ever fills against a models.Set, not a
+// precomputed price/amount/fee). In live.ModeShadow it additionally runs o
+// through Exchange.ExecuteOrder, which fills it against the pair's
+// models.Set like a historical run; no order is sent to an exchange, that
+// second fill is discarded, and only the paper fill is fed back into
+// Portfolio/Statistic.
+func (bt *BackTest) processLiveOrderEvent(o order.Event) error {
+	matcher := bt.liveMatchers[o.Pair().String()]
+	if matcher == nil {
+		return fmt.Errorf("live: no matcher configured for pair %v", o.Pair())
+	}
+
+	d := bt.Datas.GetDataForCurrency(o)
+	latest := d.Latest()
+	paperFill := matcher.Match(live.Order{
+		Price:  o.GetPrice(),
+		Amount: o.GetAmount(),
+		IsBuy:  o.GetDirection() == order.Buy,
+	}, latest.GetHighPrice(), latest.GetLowPrice(), 0, 0)
+
+	f := fill.New(o, paperFill.ExecutedPrice, paperFill.FilledAmount, paperFill.Fee)
+
+	if bt.LiveMode == live.ModeShadow {
+		if _, err := bt.Exchange.ExecuteOrder(o, bt.Datas, bt.Models[o.Pair().String()]); err != nil {
+			return fmt.Errorf("shadow exchange ExecuteOrder: %w", err)
+		}
+	}
+
+	bt.EventQueue.AppendEvent(f)
+	return nil
+}
+
+// advanceClock moves bt.Clock to t as each data event is processed, so
+// Strategy, Portfolio and Exchange read a deterministic, replayable "now"
+// off bt.Clock instead of the wall clock - see common.FakeClock. bt.Clock
+// is a common.RealClock for live runs, which ignores Set, so this is a
+// no-op there.
+func (bt *BackTest) advanceClock(t time.Time) {
+	if s, ok := bt.Clock.(interface{ Set(time.Time) }); ok {
+		s.Set(t)
+	}
+}
+
+func (bt *BackTest) processFillEvent(f fill.Event) error {
+	if _, err := bt.Portfolio.OnFill(f, bt.Datas); err != nil {
+		return fmt.Errorf("portfolio OnFill: %w", err)
+	}
+	return bt.Statistic.SetEventForOffset(f)
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts away time.Now() so BackTest and its handlers can be driven
+// by a deterministic, replayable notion of "now" instead of the wall clock.
+// Live-data runs use RealClock; every other data mode defaults to a
+// FakeClock derived from the first candle in the stream.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is the default Clock used for live trading, where "now" really
+// must mean the wall clock.
+type RealClock struct{}
+
+// Now returns the current wall-clock time.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a manually-advanced Clock. BackTest advances it to each
+// candle's timestamp as it is processed, so two runs over the same input
+// produce byte-for-byte identical output regardless of when they were
+// executed.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock fixed at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the clock's current fixed instant.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to t. It will not move the clock backwards, since
+// BackTest only ever advances through a monotonically increasing candle
+// stream.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
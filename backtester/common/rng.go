@@ -0,0 +1,41 @@
+package common
+
+import "math/rand"
+
+// RNG abstracts away math/rand so BackTest and its handlers (partial-fill
+// simulation, random parameter sampling, etc.) can be driven by a seeded,
+// reproducible source of randomness instead of the global generator.
+type RNG interface {
+	Float64() float64
+	Intn(n int) int
+}
+
+// SeededRNG is an RNG backed by a *rand.Rand constructed from a fixed seed,
+// so two runs created with the same seed draw exactly the same sequence.
+type SeededRNG struct {
+	seed int64
+	r    *rand.Rand
+}
+
+// NewSeededRNG returns a SeededRNG seeded with seed.
+func NewSeededRNG(seed int64) *SeededRNG {
+	return &SeededRNG{
+		seed: seed,
+		r:    rand.New(rand.NewSource(seed)), //nolint:gosec // deterministic backtesting, not cryptographic use
+	}
+}
+
+// Seed returns the seed this RNG was constructed with.
+func (s *SeededRNG) Seed() int64 {
+	return s.seed
+}
+
+// Float64 returns the next pseudo-random float64 in [0.0, 1.0).
+func (s *SeededRNG) Float64() float64 {
+	return s.r.Float64()
+}
+
+// Intn returns the next pseudo-random int in [0, n).
+func (s *SeededRNG) Intn(n int) int {
+	return s.r.Intn(n)
+}
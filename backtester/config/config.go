@@ -0,0 +1,153 @@
+// Package config describes the on-disk format of a backtester strategy
+// config: which exchange/currency pairs to trade, where their candle data
+// comes from, which strategy and models to run, and how much starting
+// capital to allocate.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/models"
+)
+
+var (
+	// ErrNoCurrencySettings is returned when a Config names no currency
+	// pairs to trade.
+	ErrNoCurrencySettings = errors.New("no currency settings set")
+	// ErrBadInitialFunds is returned when a CurrencySettings entry's
+	// InitialFunds is not a positive amount.
+	ErrBadInitialFunds = errors.New("initial funds must be greater than 0")
+	// ErrUnsetAsset is returned when a CurrencySettings entry names no
+	// asset type.
+	ErrUnsetAsset = errors.New("asset unset")
+	// ErrStartEndUnset is returned when a date-ranged data source is
+	// missing its start or end date.
+	ErrStartEndUnset = errors.New("start and end date must be set")
+)
+
+// Config is the top-level description of a single backtest run.
+type Config struct {
+	// GoCryptoTraderConfigPath points at the GoCryptoTrader config used to
+	// load and authenticate the exchanges CurrencySettings trades against.
+	GoCryptoTraderConfigPath string `json:"goCryptoTraderConfigPath" yaml:"goCryptoTraderConfigPath"`
+
+	CurrencySettings  []CurrencySettings `json:"currencySettings" yaml:"currencySettings"`
+	DataSettings      DataSettings       `json:"dataSettings" yaml:"dataSettings"`
+	StrategySettings  StrategySettings   `json:"strategySettings" yaml:"strategySettings"`
+	PortfolioSettings PortfolioSettings  `json:"portfolioSettings" yaml:"portfolioSettings"`
+	StatisticSettings StatisticSettings  `json:"statisticSettings" yaml:"statisticSettings"`
+}
+
+// CurrencySettings configures one currency pair's exchange, asset type,
+// starting capital and trading cost models.
+type CurrencySettings struct {
+	ExchangeName  string `json:"exchangeName" yaml:"exchangeName"`
+	Asset         string `json:"asset" yaml:"asset"`
+	Base          string `json:"base" yaml:"base"`
+	Quote         string `json:"quote" yaml:"quote"`
+	PairDelimiter string `json:"pairDelimiter" yaml:"pairDelimiter"`
+
+	InitialFunds float64 `json:"initialFunds" yaml:"initialFunds"`
+
+	// MakerFee and TakerFee are the legacy flat-percentage fees. NewFromConfig
+	// does not read them: a pair whose MarketModels names no fee model
+	// trades with no fee.
+	MakerFee float64 `json:"makerFee" yaml:"makerFee"`
+	TakerFee float64 `json:"takerFee" yaml:"takerFee"`
+
+	// MarketModels names the fee, slippage, impact and partial-fill
+	// models this pair trades under; see models.Spec.
+	MarketModels models.Spec `json:"marketModels" yaml:"marketModels"`
+}
+
+// DataSettings names where a run's candle data comes from. Exactly one of
+// CSVData, DatabaseData, APIData or LiveData should be set, matching
+// DataType.
+type DataSettings struct {
+	DataType string        `json:"dataType" yaml:"dataType"`
+	Interval time.Duration `json:"interval" yaml:"interval"`
+
+	CSVData      *CSVData      `json:"csvData,omitempty" yaml:"csvData,omitempty"`
+	DatabaseData *DatabaseData `json:"databaseData,omitempty" yaml:"databaseData,omitempty"`
+	APIData      *APIData      `json:"apiData,omitempty" yaml:"apiData,omitempty"`
+	LiveData     *LiveData     `json:"liveData,omitempty" yaml:"liveData,omitempty"`
+}
+
+// CSVData loads candles from a local CSV file.
+type CSVData struct {
+	FullPath string `json:"fullPath" yaml:"fullPath"`
+}
+
+// DatabaseData loads candles from GoCryptoTrader's own candle database.
+type DatabaseData struct {
+	StartDate        time.Time   `json:"startDate" yaml:"startDate"`
+	EndDate          time.Time   `json:"endDate" yaml:"endDate"`
+	InclusiveEndDate bool        `json:"inclusiveEndDate" yaml:"inclusiveEndDate"`
+	ConfigOverride   interface{} `json:"configOverride,omitempty" yaml:"configOverride,omitempty"`
+}
+
+// APIData loads candles from an exchange's REST candle history endpoint.
+type APIData struct {
+	StartDate        time.Time `json:"startDate" yaml:"startDate"`
+	EndDate          time.Time `json:"endDate" yaml:"endDate"`
+	InclusiveEndDate bool      `json:"inclusiveEndDate" yaml:"inclusiveEndDate"`
+}
+
+// LiveData streams candles from an exchange in real time rather than
+// replaying a fixed historical range.
+type LiveData struct {
+	APIKeyOverride      string `json:"apiKeyOverride,omitempty" yaml:"apiKeyOverride,omitempty"`
+	APISecretOverride   string `json:"apiSecretOverride,omitempty" yaml:"apiSecretOverride,omitempty"`
+	APIClientIDOverride string `json:"apiClientIdOverride,omitempty" yaml:"apiClientIdOverride,omitempty"`
+	API2FAOverride      string `json:"api2FAOverride,omitempty" yaml:"api2FAOverride,omitempty"`
+	RealOrders          bool   `json:"realOrders" yaml:"realOrders"`
+
+	// Mode selects how orders are handled while trading against this live
+	// stream: "real" (the default) fills them through the regular Exchange
+	// handler, "paper" fills them against an in-memory matcher instead, and
+	// "shadow" does both, keeping only the matcher's fill. No mode sends an
+	// order to an exchange. See live.Mode.
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// StrategySettings names the strategy to run and its parameters.
+type StrategySettings struct {
+	Name                         string                 `json:"name" yaml:"name"`
+	SimultaneousSignalProcessing bool                   `json:"simultaneousSignalProcessing" yaml:"simultaneousSignalProcessing"`
+	CustomSettings               map[string]interface{} `json:"customSettings,omitempty" yaml:"customSettings,omitempty"`
+}
+
+// MinMax bounds an order size a Portfolio's sizing rules may produce.
+type MinMax struct {
+	MinimumSize  float64 `json:"minimumSize" yaml:"minimumSize"`
+	MaximumSize  float64 `json:"maximumSize" yaml:"maximumSize"`
+	MaximumTotal float64 `json:"maximumTotal" yaml:"maximumTotal"`
+}
+
+// PortfolioSettings bounds the order sizes a Portfolio may place.
+type PortfolioSettings struct {
+	BuySide  MinMax `json:"buySide" yaml:"buySide"`
+	SellSide MinMax `json:"sellSide" yaml:"sellSide"`
+}
+
+// StatisticSettings configures the risk-adjusted return calculations a
+// Statistic handler reports.
+type StatisticSettings struct {
+	RiskFreeRate float64 `json:"riskFreeRate" yaml:"riskFreeRate"`
+}
+
+// ReadConfigFromFile reads and unmarshals the Config at path.
+func ReadConfigFromFile(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err = json.Unmarshal(b, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,88 @@
+// Command gen-vector captures a passing backtest run as a new conformance
+// vector file. It is meant to be run by a strategy author once they are
+// satisfied with a run's behaviour, to lock it in as a regression vector:
+//
+//	go run ./backtester/conformance/gen-vector -config mystrategy.strat \
+//		-id my-strategy-basic -out backtester/conformance/testdata/my-strategy-basic.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/backtest"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "gen-vector:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	configPath := flag.String("config", "", "path to the strategy config used to produce the run")
+	id := flag.String("id", "", "vector id to record the run under")
+	description := flag.String("description", "", "human readable description of what this vector covers")
+	out := flag.String("out", "", "path to write the generated vector file to")
+	flag.Parse()
+
+	if *configPath == "" || *id == "" || *out == "" {
+		flag.Usage()
+		return fmt.Errorf("config, id and out are required")
+	}
+
+	cfg, err := config.ReadConfigFromFile(*configPath)
+	if err != nil {
+		return fmt.Errorf("reading strategy config: %w", err)
+	}
+
+	bot, err := newBot(cfg)
+	if err != nil {
+		return fmt.Errorf("setting up exchanges: %w", err)
+	}
+
+	bt, err := backtest.NewFromConfig(cfg, "", "", bot)
+	if err != nil {
+		return fmt.Errorf("setting up backtest: %w", err)
+	}
+
+	rec, err := bt.CaptureConformanceVector(*id, *description)
+	if err != nil {
+		return fmt.Errorf("running backtest: %w", err)
+	}
+
+	b, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(*out, b, 0o644); err != nil {
+		return fmt.Errorf("writing vector: %w", err)
+	}
+
+	fmt.Printf("wrote conformance vector %q (%d events) to %s\n", rec.Meta.ID, len(rec.Post.Events), *out)
+	return nil
+}
+
+// newBot loads an engine.Engine with every exchange cfg's currencies trade
+// on, so backtest.NewFromConfig has a live bot to source exchange
+// connections from instead of the nil it would otherwise be handed.
+func newBot(cfg *config.Config) (*engine.Engine, error) {
+	bot, err := engine.NewFromSettings(&engine.Settings{
+		ConfigFile:   cfg.GoCryptoTraderConfigPath,
+		EnableDryRun: true,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, cs := range cfg.CurrencySettings {
+		if err = bot.LoadExchange(cs.ExchangeName, false, nil); err != nil {
+			return nil, fmt.Errorf("loading exchange %s: %w", cs.ExchangeName, err)
+		}
+	}
+	return bot, nil
+}
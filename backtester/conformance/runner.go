@@ -0,0 +1,72 @@
+package conformance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Result is the outcome of replaying a single vector.
+type Result struct {
+	Vector *Vector
+	Passed bool
+	Reason string
+}
+
+// Diff compares a produced event stream and statistics hash against the
+// expected values recorded in a vector's Post block. Comparison is done on
+// the compact JSON serialisation of both event streams, so whitespace inside
+// a payload never causes a false mismatch; key order inside a payload is
+// compared as written and is not normalised.
+func Diff(v *Vector, actualEvents []Event, actualStatisticsHash string) (*Result, error) {
+	if v == nil {
+		return nil, fmt.Errorf("conformance: nil vector")
+	}
+
+	expected, err := canonicalEvents(v.Post.Events)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := canonicalEvents(actualEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(expected, actual) {
+		return &Result{
+			Vector: v,
+			Passed: false,
+			Reason: fmt.Sprintf("event stream mismatch:\n--- expected ---\n%s\n--- actual ---\n%s", expected, actual),
+		}, nil
+	}
+
+	if v.Post.StatisticsHash != actualStatisticsHash {
+		return &Result{
+			Vector: v,
+			Passed: false,
+			Reason: fmt.Sprintf("statistics hash mismatch: expected %s, got %s", v.Post.StatisticsHash, actualStatisticsHash),
+		}, nil
+	}
+
+	return &Result{Vector: v, Passed: true}, nil
+}
+
+// canonicalEvents serialises an event stream deterministically: json.Marshal
+// on a slice preserves event order, Event's fields are emitted in a fixed
+// order, and each RawMessage payload is compacted but otherwise kept as is.
+func canonicalEvents(events []Event) ([]byte, error) {
+	if events == nil {
+		events = []Event{}
+	}
+	return json.Marshal(events)
+}
+
+// HashStatistics produces the deterministic digest stored in a vector's
+// Post.StatisticsHash field. Callers pass in the canonical JSON
+// representation of whatever statistics.Statistic snapshot the run produced.
+func HashStatistics(canonicalStatisticsJSON []byte) string {
+	sum := sha256.Sum256(canonicalStatisticsJSON)
+	return hex.EncodeToString(sum[:])
+}
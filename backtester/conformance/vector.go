@@ -0,0 +1,167 @@
+// Package conformance defines a portable, versioned corpus of backtest
+// vectors and the tooling to load, generate and diff them. A vector is a
+// self-contained description of a strategy run: the inputs that produced it
+// and the outputs it must reproduce exactly. Any backtester implementation,
+// in this module or otherwise, can be validated against the same corpus.
+package conformance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CorpusVersion is bumped whenever the vector schema changes in a
+// backwards-incompatible way. Vectors embed the schema version they were
+// generated against so a runner can refuse to execute stale vectors.
+const CorpusVersion = 1
+
+var (
+	// ErrNoVectors is returned when a vector directory contains no usable
+	// vector files.
+	ErrNoVectors = errors.New("no conformance vectors found")
+	// ErrUnsupportedVersion is returned when a vector declares a schema
+	// version newer than this runner understands.
+	ErrUnsupportedVersion = errors.New("vector schema version unsupported")
+)
+
+// Meta describes identifying information about a vector.
+type Meta struct {
+	ID          string `json:"id" yaml:"id"`
+	Version     int    `json:"version" yaml:"version"`
+	Description string `json:"description" yaml:"description"`
+	Strategy    string `json:"strategy" yaml:"strategy"`
+	Seed        int64  `json:"seed" yaml:"seed"`
+}
+
+// Pre describes the state the backtest must be configured with before the
+// input stream is replayed.
+type Pre struct {
+	InitialFunds   float64           `json:"initialFunds" yaml:"initialFunds"`
+	FeeModel       string            `json:"feeModel" yaml:"feeModel"`
+	Exchange       string            `json:"exchange" yaml:"exchange"`
+	Base           string            `json:"base" yaml:"base"`
+	Quote          string            `json:"quote" yaml:"quote"`
+	Asset          string            `json:"asset" yaml:"asset"`
+	CustomSettings map[string]string `json:"customSettings,omitempty" yaml:"customSettings,omitempty"`
+}
+
+// Candle is a single deterministic candle in the input stream.
+type Candle struct {
+	Time   time.Time `json:"time" yaml:"time"`
+	Open   float64   `json:"open" yaml:"open"`
+	High   float64   `json:"high" yaml:"high"`
+	Low    float64   `json:"low" yaml:"low"`
+	Close  float64   `json:"close" yaml:"close"`
+	Volume float64   `json:"volume" yaml:"volume"`
+}
+
+// Input is the deterministic candle stream replayed through the strategy.
+type Input struct {
+	Interval time.Duration `json:"interval" yaml:"interval"`
+	Candles  []Candle      `json:"candles" yaml:"candles"`
+}
+
+// Event is a single expected output event, e.g. an order being placed or a
+// portfolio delta being applied. Payload is kept opaque so vectors can
+// describe event types the runner doesn't know about without failing to
+// parse.
+type Event struct {
+	Type    string          `json:"type" yaml:"type"`
+	Time    time.Time       `json:"time" yaml:"time"`
+	Payload json.RawMessage `json:"payload" yaml:"payload"`
+}
+
+// Post describes the expected outcome of replaying Input against Pre.
+type Post struct {
+	Events         []Event `json:"events" yaml:"events"`
+	StatisticsHash string  `json:"statisticsHash" yaml:"statisticsHash"`
+}
+
+// Vector is a single, self-contained conformance test case.
+type Vector struct {
+	Meta  Meta  `json:"meta" yaml:"meta"`
+	Pre   Pre   `json:"pre" yaml:"pre"`
+	Input Input `json:"input" yaml:"input"`
+	Post  Post  `json:"post" yaml:"post"`
+
+	// Path is set by LoadVectors to the file the vector was read from, it
+	// is never (de)serialised.
+	Path string `json:"-" yaml:"-"`
+}
+
+// LoadVector reads and parses a single vector file. Both JSON and YAML are
+// accepted, selected by file extension.
+func LoadVector(path string) (*Vector, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: reading vector %q: %w", path, err)
+	}
+
+	v := &Vector{}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, v)
+	default:
+		err = json.Unmarshal(raw, v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("conformance: parsing vector %q: %w", path, err)
+	}
+	if v.Meta.Version > CorpusVersion {
+		return nil, fmt.Errorf("%w: vector %q wants version %d, runner supports up to %d", ErrUnsupportedVersion, path, v.Meta.Version, CorpusVersion)
+	}
+	v.Path = path
+	return v, nil
+}
+
+// LoadVectors loads every vector file in dir, sorted by vector ID so runs
+// are deterministic regardless of filesystem ordering.
+func LoadVectors(dir string) ([]*Vector, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	yamlMatches, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	matches = append(matches, yamlMatches...)
+	ymlMatches, err := filepath.Glob(filepath.Join(dir, "*.yml"))
+	if err != nil {
+		return nil, err
+	}
+	matches = append(matches, ymlMatches...)
+
+	if len(matches) == 0 {
+		return nil, ErrNoVectors
+	}
+
+	vectors := make([]*Vector, 0, len(matches))
+	for _, m := range matches {
+		v, err := LoadVector(m)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, v)
+	}
+	sort.Slice(vectors, func(i, j int) bool {
+		return vectors[i].Meta.ID < vectors[j].Meta.ID
+	})
+	return vectors, nil
+}
+
+// Save writes the vector to path as canonically-formatted JSON.
+func (v *Vector) Save(path string) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0o644)
+}
@@ -0,0 +1,49 @@
+package conformance
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLoadVectors(t *testing.T) {
+	t.Parallel()
+	vectors, err := LoadVectors("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) != 1 {
+		t.Fatalf("expected 1 vector, received %d", len(vectors))
+	}
+	if vectors[0].Meta.ID != "dca-basic" {
+		t.Errorf("expected dca-basic, received %s", vectors[0].Meta.ID)
+	}
+
+	_, err = LoadVectors("testdata/does-not-exist")
+	if !errors.Is(err, ErrNoVectors) {
+		t.Errorf("expected %v, received %v", ErrNoVectors, err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	v := &Vector{
+		Meta: Meta{ID: "test"},
+		Post: Post{StatisticsHash: "abc"},
+	}
+
+	result, err := Diff(v, nil, "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Passed {
+		t.Errorf("expected pass, received failure: %s", result.Reason)
+	}
+
+	result, err = Diff(v, nil, "def")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Passed {
+		t.Error("expected failure for mismatched statistics hash")
+	}
+}
@@ -0,0 +1,48 @@
+// Package exchange fills order.Events against a currency pair's configured
+// models.Set, replacing the fixed CurrencySettings.MakerFee/TakerFee
+// percentages that used to be the only way a backtest could cost a trade.
+package exchange
+
+import (
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/models"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
+)
+
+// Exchange turns order.Events into fill.Events. It holds no per-run state
+// of its own - every input it needs (the candle an order fills against,
+// the models.Set governing its cost) is passed in by the caller, so a
+// single Exchange is safe to share across concurrent BackTest runs.
+type Exchange struct{}
+
+// ExecuteOrder fills o against the latest candle for its pair in datas.
+// set's SlippageModel and ImpactModel adjust the executed price away from
+// o's requested price, set's PartialFillModel decides how much of the
+// requested amount actually fills, and set's FeeModel charges against the
+// result - together replacing the old hard-coded maker/taker percentages.
+// ImpactModel is given the candle's volume as averageDailyVolume and a
+// sigma of 0, so SquareRootImpact adds no impact to the price here.
+func (e *Exchange) ExecuteOrder(o order.Event, datas *data.HandlerPerCurrency, set models.Set) (fill.Event, error) {
+	d := datas.GetDataForCurrency(o)
+	if d == nil {
+		return nil, fmt.Errorf("exchange: no data stream configured for pair %v", o.Pair())
+	}
+	latest := d.Latest()
+	isBuy := o.GetDirection() == order.Buy
+
+	price := set.Slippage.Slip(latest.GetHighPrice(), latest.GetLowPrice(), o.GetPrice(), isBuy)
+	impact := set.Impact.Impact(o.GetAmount(), latest.GetVolume(), 0)
+	if isBuy {
+		price *= 1 + impact
+	} else {
+		price *= 1 - impact
+	}
+
+	amount := o.GetAmount() * set.PartialFill.FillRatio(o.GetAmount())
+	fee := set.Fee.Fee(price, amount, false)
+
+	return fill.New(o, price, amount, fee), nil
+}
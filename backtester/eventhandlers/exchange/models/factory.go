@@ -0,0 +1,105 @@
+package models
+
+import "github.com/thrasher-corp/gocryptotrader/backtester/common"
+
+// Spec names which model implementation each currency's MarketModels
+// config block should build, along with their shared parameter bag. It
+// mirrors config.CurrencySettings.MarketModels, e.g.:
+//
+//	marketModels:
+//	  fee: tiered-volume
+//	  slippage: vwap
+//	  impact: sqrt
+//	  partialFill: probabilistic
+//	  params:
+//	    weight: 0.5
+//	    k: 1
+//	    fullFillChance: 0.8
+//	    minFillRatio: 0.2
+//	  tiers:
+//	    - minVolume: 0
+//	      makerBps: 10
+//	      takerBps: 20
+//	    - minVolume: 1000
+//	      makerBps: 5
+//	      takerBps: 10
+//
+// Fee: fixed-bps reads Params.makerBps/takerBps directly, since it has no
+// schedule; Fee: tiered-volume reads Tiers instead, since a single
+// maker/taker pair can't express more than one rung.
+type Spec struct {
+	Fee         string             `json:"fee" yaml:"fee"`
+	Slippage    string             `json:"slippage" yaml:"slippage"`
+	Impact      string             `json:"impact" yaml:"impact"`
+	PartialFill string             `json:"partialFill" yaml:"partialFill"`
+	Params      map[string]float64 `json:"params" yaml:"params"`
+	// Tiers configures a tiered-volume fee schedule; see TieredVolumeFee.
+	// Ignored by every other Fee spec.
+	Tiers []VolumeTier `json:"tiers,omitempty" yaml:"tiers,omitempty"`
+}
+
+// Set bundles one of each model, ready to be attached to an Exchange for a
+// given currency pair.
+type Set struct {
+	Fee         FeeModel
+	Slippage    SlippageModel
+	Impact      ImpactModel
+	PartialFill PartialFillModel
+}
+
+// Build constructs a Set from spec. Any model spec names left empty
+// default to a conservative no-op (zero fee, zero slippage, zero impact,
+// full fill), so a currency that only cares about e.g. fees doesn't have
+// to name every model.
+func Build(spec Spec, rng common.RNG) Set {
+	set := Set{
+		Fee:         noFee{},
+		Slippage:    noSlippage{},
+		Impact:      noImpact{},
+		PartialFill: fullFill{},
+	}
+
+	switch spec.Fee {
+	case "fixed-bps":
+		set.Fee = FixedBPSFee{MakerBPS: spec.Params["makerBps"], TakerBPS: spec.Params["takerBps"]}
+	case "tiered-volume":
+		set.Fee = &TieredVolumeFee{Tiers: spec.Tiers}
+	}
+
+	switch spec.Slippage {
+	case "vwap":
+		set.Slippage = VWAPSlippage{Weight: spec.Params["weight"]}
+	}
+
+	switch spec.Impact {
+	case "sqrt":
+		set.Impact = SquareRootImpact{K: spec.Params["k"]}
+	}
+
+	switch spec.PartialFill {
+	case "probabilistic":
+		set.PartialFill = ProbabilisticPartialFill{
+			RNG:            rng,
+			FullFillChance: spec.Params["fullFillChance"],
+			MinFillRatio:   spec.Params["minFillRatio"],
+		}
+	}
+
+	return set
+}
+
+type noFee struct{}
+
+func (noFee) Fee(float64, float64, bool) float64 { return 0 }
+
+type noSlippage struct{}
+
+func (noSlippage) Slip(_, _, requestedPrice float64, _ bool) float64 { return requestedPrice }
+
+type noImpact struct{}
+
+func (noImpact) Impact(float64, float64, float64) float64 { return 0 }
+
+type fullFill struct{}
+
+func (fullFill) FillRatio(float64) float64 { return 1 }
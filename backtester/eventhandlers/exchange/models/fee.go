@@ -0,0 +1,72 @@
+// Package models provides pluggable fee, slippage and market-impact models
+// for the backtester's Exchange handler. They replace the fixed
+// CurrencySettings.MakerFee/TakerFee arithmetic that used to be the only
+// option, so a run can model the cost of trading sizeable orders
+// realistically instead of assuming a flat, size-independent fee.
+package models
+
+// FeeModel computes the fee charged for an order of the given size at the
+// given price.
+type FeeModel interface {
+	Fee(price, amount float64, isMaker bool) float64
+}
+
+// FixedBPSFee charges a flat basis-point fee regardless of order size -
+// the direct replacement for the old hard-coded
+// CurrencySettings.MakerFee/TakerFee percentages.
+type FixedBPSFee struct {
+	MakerBPS float64
+	TakerBPS float64
+}
+
+// Fee returns price * amount * the applicable bps rate / 10000.
+func (f FixedBPSFee) Fee(price, amount float64, isMaker bool) float64 {
+	bps := f.TakerBPS
+	if isMaker {
+		bps = f.MakerBPS
+	}
+	return price * amount * bps / 10000
+}
+
+// VolumeTier is one rung of a TieredVolumeFee schedule. A tier applies once
+// cumulative traded volume reaches MinVolume, until the next tier's
+// MinVolume is reached.
+type VolumeTier struct {
+	MinVolume float64 `json:"minVolume" yaml:"minVolume"`
+	MakerBPS  float64 `json:"makerBps" yaml:"makerBps"`
+	TakerBPS  float64 `json:"takerBps" yaml:"takerBps"`
+}
+
+// TieredVolumeFee charges a fee rate that decreases as CumulativeVolume
+// grows, modelling the volume-based schedules real exchanges publish.
+// Tiers must be supplied in ascending MinVolume order.
+type TieredVolumeFee struct {
+	Tiers            []VolumeTier
+	CumulativeVolume float64
+}
+
+// Fee charges the rate of the highest tier reached by CumulativeVolume so
+// far, then adds this order's notional to CumulativeVolume for next time.
+// A TieredVolumeFee with no Tiers configured charges nothing rather than
+// panicking, since it is an exported, directly-constructible type and
+// nothing stops a caller from building a zero-value one.
+func (f *TieredVolumeFee) Fee(price, amount float64, isMaker bool) float64 {
+	if len(f.Tiers) == 0 {
+		return 0
+	}
+
+	tier := f.Tiers[0]
+	for _, t := range f.Tiers {
+		if f.CumulativeVolume >= t.MinVolume {
+			tier = t
+		}
+	}
+
+	bps := tier.TakerBPS
+	if isMaker {
+		bps = tier.MakerBPS
+	}
+	fee := price * amount * bps / 10000
+	f.CumulativeVolume += price * amount
+	return fee
+}
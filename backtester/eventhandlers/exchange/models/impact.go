@@ -0,0 +1,27 @@
+package models
+
+import "math"
+
+// ImpactModel estimates the additional, size-driven price impact an order
+// causes beyond ordinary slippage, expressed as a proportional price
+// adjustment to be applied on top of a SlippageModel's result.
+type ImpactModel interface {
+	// Impact returns the proportional price move an order of orderSize
+	// causes, given the instrument's averageDailyVolume and its recent
+	// volatility sigma.
+	Impact(orderSize, averageDailyVolume, sigma float64) float64
+}
+
+// SquareRootImpact implements the standard square-root market impact
+// model: impact = k * sigma * sqrt(orderSize / averageDailyVolume).
+type SquareRootImpact struct {
+	K float64
+}
+
+// Impact implements ImpactModel.
+func (s SquareRootImpact) Impact(orderSize, averageDailyVolume, sigma float64) float64 {
+	if averageDailyVolume <= 0 {
+		return 0
+	}
+	return s.K * sigma * math.Sqrt(orderSize/averageDailyVolume)
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+)
+
+func TestFixedBPSFee(t *testing.T) {
+	f := FixedBPSFee{MakerBPS: 10, TakerBPS: 20}
+	if got := f.Fee(100, 2, true); got != 0.2 {
+		t.Errorf("maker fee: expected 0.2, got %v", got)
+	}
+	if got := f.Fee(100, 2, false); got != 0.4 {
+		t.Errorf("taker fee: expected 0.4, got %v", got)
+	}
+}
+
+func TestTieredVolumeFee(t *testing.T) {
+	f := &TieredVolumeFee{Tiers: []VolumeTier{
+		{MinVolume: 0, MakerBPS: 10, TakerBPS: 20},
+		{MinVolume: 1000, MakerBPS: 5, TakerBPS: 10},
+	}}
+	if got := f.Fee(100, 10, false); got != 2 {
+		t.Errorf("first tier fee: expected 2, got %v", got)
+	}
+	if f.CumulativeVolume != 1000 {
+		t.Errorf("expected cumulative volume 1000, got %v", f.CumulativeVolume)
+	}
+	if got := f.Fee(100, 6, false); got != 0.6 {
+		t.Errorf("second tier fee: expected 0.6, got %v", got)
+	}
+}
+
+func TestTieredVolumeFeeNoTiers(t *testing.T) {
+	f := &TieredVolumeFee{}
+	if got := f.Fee(100, 5, false); got != 0 {
+		t.Errorf("fee with no tiers configured: expected 0, got %v", got)
+	}
+}
+
+func TestVWAPSlippage(t *testing.T) {
+	v := VWAPSlippage{Weight: 1}
+	if got := v.Slip(110, 90, 105, true); got != 105 {
+		t.Errorf("weight 1 should return requested price, got %v", got)
+	}
+	v = VWAPSlippage{Weight: 0}
+	if got := v.Slip(110, 90, 105, true); got != 100 {
+		t.Errorf("weight 0 should return candle midpoint, got %v", got)
+	}
+}
+
+func TestSquareRootImpact(t *testing.T) {
+	s := SquareRootImpact{K: 1}
+	if got := s.Impact(0, 1000, 0.1); got != 0 {
+		t.Errorf("zero order size should have zero impact, got %v", got)
+	}
+	if got := s.Impact(100, 0, 0.1); got != 0 {
+		t.Errorf("zero ADV should have zero impact, got %v", got)
+	}
+}
+
+func TestProbabilisticPartialFill(t *testing.T) {
+	p := ProbabilisticPartialFill{RNG: common.NewSeededRNG(1), FullFillChance: 1, MinFillRatio: 0.5}
+	if got := p.FillRatio(100); got != 1 {
+		t.Errorf("full fill chance 1 should always fill fully, got %v", got)
+	}
+}
+
+func TestBuildTieredVolumeFee(t *testing.T) {
+	set := Build(Spec{
+		Fee: "tiered-volume",
+		Tiers: []VolumeTier{
+			{MinVolume: 0, MakerBPS: 10, TakerBPS: 20},
+			{MinVolume: 1000, MakerBPS: 5, TakerBPS: 10},
+		},
+	}, common.NewSeededRNG(1))
+	if got := set.Fee.Fee(100, 10, false); got != 2 {
+		t.Errorf("first tier fee: expected 2, got %v", got)
+	}
+	if got := set.Fee.Fee(100, 6, false); got != 0.6 {
+		t.Errorf("second tier fee: expected 0.6, got %v", got)
+	}
+}
+
+func TestBuildDefaults(t *testing.T) {
+	set := Build(Spec{}, common.NewSeededRNG(1))
+	if got := set.Fee.Fee(100, 1, false); got != 0 {
+		t.Errorf("default fee model should charge 0, got %v", got)
+	}
+	if got := set.Slippage.Slip(110, 90, 105, true); got != 105 {
+		t.Errorf("default slippage model should return requested price, got %v", got)
+	}
+	if got := set.Impact.Impact(100, 1000, 0.1); got != 0 {
+		t.Errorf("default impact model should return 0, got %v", got)
+	}
+	if got := set.PartialFill.FillRatio(100); got != 1 {
+		t.Errorf("default partial fill model should fill fully, got %v", got)
+	}
+}
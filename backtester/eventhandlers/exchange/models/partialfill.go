@@ -0,0 +1,29 @@
+package models
+
+import "github.com/thrasher-corp/gocryptotrader/backtester/common"
+
+// PartialFillModel decides what fraction of a requested order size
+// actually fills, modelling thin order books that can't always absorb an
+// order in full.
+type PartialFillModel interface {
+	// FillRatio returns the fraction, in (0, 1], of requestedAmount that
+	// fills.
+	FillRatio(requestedAmount float64) float64
+}
+
+// ProbabilisticPartialFill draws a fill ratio from RNG on every call: with
+// probability FullFillChance the order fills completely, otherwise it
+// fills a uniformly random fraction between MinFillRatio and 1.
+type ProbabilisticPartialFill struct {
+	RNG            common.RNG
+	FullFillChance float64
+	MinFillRatio   float64
+}
+
+// FillRatio implements PartialFillModel.
+func (p ProbabilisticPartialFill) FillRatio(_ float64) float64 {
+	if p.RNG.Float64() < p.FullFillChance {
+		return 1
+	}
+	return p.MinFillRatio + p.RNG.Float64()*(1-p.MinFillRatio)
+}
@@ -0,0 +1,30 @@
+package models
+
+import "math"
+
+// SlippageModel estimates the price an order actually executes at, given
+// where it crossed inside the candle that filled it.
+type SlippageModel interface {
+	// Slip returns the executed price for an order requested at
+	// requestedPrice against a candle spanning [candleLow, candleHigh].
+	Slip(candleHigh, candleLow, requestedPrice float64, isBuy bool) float64
+}
+
+// VWAPSlippage assumes execution lands somewhere between the candle's
+// midpoint and the requested price, controlled by Weight: 0 always
+// executes at the candle's midpoint (maximum slippage), 1 always executes
+// at the requested price (no slippage). The result is clamped to the
+// candle's range, since no fill should execute outside it.
+type VWAPSlippage struct {
+	Weight float64
+}
+
+// Slip implements SlippageModel.
+func (v VWAPSlippage) Slip(candleHigh, candleLow, requestedPrice float64, isBuy bool) float64 {
+	mid := (candleHigh + candleLow) / 2
+	executed := mid + (requestedPrice-mid)*v.Weight
+	if isBuy {
+		return math.Min(candleHigh, math.Max(executed, candleLow))
+	}
+	return math.Max(candleLow, math.Min(executed, candleHigh))
+}
@@ -0,0 +1,25 @@
+package registry
+
+import (
+	"errors"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
+)
+
+// BuiltinLoader wraps the module's own strategies.LoadStrategyByName so the
+// compiled-in DCA/RSI strategies participate in a Registry the same way any
+// external loader does.
+type BuiltinLoader struct{}
+
+// Load resolves name against the compiled-in strategy list.
+func (BuiltinLoader) Load(name string, useSimultaneousSignalProcessing bool) (strategies.Handler, bool, error) {
+	h, err := strategies.LoadStrategyByName(name, useSimultaneousSignalProcessing)
+	if err != nil {
+		if errors.Is(err, base.ErrStrategyNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return h, true, nil
+}
@@ -0,0 +1,89 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/registry/strategyrpc"
+)
+
+// Dialer starts (or connects to) the subprocess backing a named strategy
+// and returns its dial target, e.g. "127.0.0.1:50051" or a unix socket
+// path. Spawning the subprocess, if one isn't already running, is the
+// Dialer's responsibility.
+type Dialer func(name string) (target string, err error)
+
+// GRPCLoader resolves strategy names to out-of-process implementations of
+// strategyrpc.StrategyService. Each successfully dialled connection is
+// cached and reused for the lifetime of the loader.
+type GRPCLoader struct {
+	Dial Dialer
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// Load dials (or reuses a cached connection to) the subprocess registered
+// under name, wrapping it in a strategies.Handler.
+func (g *GRPCLoader) Load(name string, _ bool) (strategies.Handler, bool, error) {
+	if g.Dial == nil {
+		return nil, false, nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.conns == nil {
+		g.conns = make(map[string]*grpc.ClientConn)
+	}
+
+	conn, ok := g.conns[name]
+	if !ok {
+		target, err := g.Dial(name)
+		if err != nil {
+			// no subprocess registered under this name; let the next
+			// loader in the registry try.
+			return nil, false, nil //nolint:nilerr // intentional: Dial not recognising name is not a failure
+		}
+
+		conn, err = grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, false, fmt.Errorf("dialling strategy service %q at %q: %w", name, target, err)
+		}
+		g.conns[name] = conn
+	}
+
+	client := strategyrpc.NewStrategyServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := client.Name(ctx, &strategyrpc.Empty{})
+	if err != nil {
+		return nil, false, fmt.Errorf("querying strategy service %q for its name: %w", name, err)
+	}
+	if resp.Name != name {
+		return nil, false, fmt.Errorf("strategy service registered as %q reported name %q", name, resp.Name)
+	}
+
+	return newRemoteStrategy(name, client), true, nil
+}
+
+// Stop closes every dialled connection.
+func (g *GRPCLoader) Stop() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var errs []error
+	for name, conn := range g.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing strategy service connection %q: %w", name, err))
+		}
+	}
+	g.conns = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+//go:build !windows
+
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+)
+
+// errPluginSymbolType is returned when a plugin exports a NewStrategy
+// symbol that isn't the expected constructor function.
+var errPluginSymbolType = errors.New("plugin NewStrategy symbol has unexpected type")
+
+// PluginLoader resolves strategy names against Go plugins (.so files) found
+// in Dir. Each plugin must export a NewStrategy symbol of type
+// `func() strategies.Handler`; the returned Handler's Name() is what
+// LoadStrategyByName matches against.
+//
+// Go plugins are only supported on platforms the plugin package itself
+// supports, which excludes Windows.
+type PluginLoader struct {
+	Dir string
+
+	opened map[string]*plugin.Plugin
+}
+
+// Load scans Dir for a plugin whose NewStrategy() reports the requested
+// name, opening (and caching) each .so encountered along the way.
+func (p *PluginLoader) Load(name string, _ bool) (strategies.Handler, bool, error) {
+	entries, err := os.ReadDir(p.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".so" {
+			continue
+		}
+
+		h, err := p.open(filepath.Join(p.Dir, entry.Name()))
+		if err != nil {
+			return nil, false, err
+		}
+		if h.Name() == name {
+			return h, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (p *PluginLoader) open(path string) (strategies.Handler, error) {
+	if p.opened == nil {
+		p.opened = make(map[string]*plugin.Plugin)
+	}
+	plug, ok := p.opened[path]
+	if !ok {
+		var err error
+		plug, err = plugin.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("opening plugin %q: %w", path, err)
+		}
+		p.opened[path] = plug
+	}
+
+	sym, err := plug.Lookup("NewStrategy")
+	if err != nil {
+		return nil, fmt.Errorf("plugin %q missing NewStrategy symbol: %w", path, err)
+	}
+	newStrategy, ok := sym.(func() strategies.Handler)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errPluginSymbolType, path)
+	}
+	return newStrategy(), nil
+}
+
+// Reset drops every previously opened plugin handle so the next Load call
+// re-opens them. Go plugins cannot be closed once opened, only forgotten.
+func (p *PluginLoader) Reset() error {
+	p.opened = nil
+	return nil
+}
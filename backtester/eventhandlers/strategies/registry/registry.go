@@ -0,0 +1,86 @@
+// Package registry lets strategies be resolved from sources other than the
+// module's compiled-in strategy list: Go plugins loaded from disk, or
+// remote processes speaking the StrategyService protocol over gRPC. This is
+// what allows BackTest to run a strategy that was never compiled into the
+// gocryptotrader binary.
+package registry
+
+import (
+	"errors"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+)
+
+// ErrStrategyNotFound is returned when no registered Loader recognises the
+// requested strategy name.
+var ErrStrategyNotFound = errors.New("strategy not found in any registered loader")
+
+// Loader resolves a single strategy by name. ok is false (with a nil error)
+// when the loader simply doesn't have a strategy under that name, so
+// Registry can keep trying the next loader; a non-nil error means the
+// loader recognised the name but failed to construct the strategy.
+type Loader interface {
+	Load(name string, useSimultaneousSignalProcessing bool) (h strategies.Handler, ok bool, err error)
+}
+
+// Registry tries each of its Loaders in order until one resolves the
+// requested strategy name. The built-in, compiled-in strategies are just
+// another Loader (see BuiltinLoader), so external strategies compose with
+// them instead of replacing them.
+type Registry struct {
+	loaders []Loader
+}
+
+// New returns a Registry that tries loaders in the order given.
+func New(loaders ...Loader) *Registry {
+	return &Registry{loaders: loaders}
+}
+
+// Register appends an additional Loader, tried after all previously
+// registered loaders.
+func (r *Registry) Register(l Loader) {
+	r.loaders = append(r.loaders, l)
+}
+
+// LoadStrategyByName resolves name against every registered Loader in
+// order, returning the first match.
+func (r *Registry) LoadStrategyByName(name string, useSimultaneousSignalProcessing bool) (strategies.Handler, error) {
+	for _, l := range r.loaders {
+		h, ok, err := l.Load(name, useSimultaneousSignalProcessing)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return h, nil
+		}
+	}
+	return nil, ErrStrategyNotFound
+}
+
+// Reset clears per-run state on every loader that has a Reset method, such
+// as PluginLoader's open plugin handles, so a Registry can be reused across
+// BackTest.Reset() calls without leaking state between runs. GRPCLoader has
+// no Reset method; its dialled connections are closed only by Stop.
+func (r *Registry) Reset() error {
+	for _, l := range r.loaders {
+		if rl, ok := l.(interface{ Reset() error }); ok {
+			if err := rl.Reset(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Stop shuts down every loader that has a Stop method, such as a
+// GRPCLoader's dialled connections.
+func (r *Registry) Stop() error {
+	for _, l := range r.loaders {
+		if sl, ok := l.(interface{ Stop() error }); ok {
+			if err := sl.Stop(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
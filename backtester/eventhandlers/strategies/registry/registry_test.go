@@ -0,0 +1,46 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+)
+
+type fakeLoader struct {
+	name    string
+	handler strategies.Handler
+	err     error
+}
+
+func (f *fakeLoader) Load(name string, _ bool) (strategies.Handler, bool, error) {
+	if f.err != nil {
+		return nil, false, f.err
+	}
+	if name != f.name {
+		return nil, false, nil
+	}
+	return f.handler, true, nil
+}
+
+func TestLoadStrategyByName(t *testing.T) {
+	t.Parallel()
+
+	r := New(&fakeLoader{name: "first"}, &fakeLoader{name: "second"})
+	_, err := r.LoadStrategyByName("unknown", false)
+	if !errors.Is(err, ErrStrategyNotFound) {
+		t.Errorf("expected %v, received %v", ErrStrategyNotFound, err)
+	}
+
+	_, err = r.LoadStrategyByName("second", false)
+	if err != nil {
+		t.Errorf("expected nil, received %v", err)
+	}
+
+	wantErr := errors.New("loader blew up")
+	r = New(&fakeLoader{err: wantErr})
+	_, err = r.LoadStrategyByName("anything", false)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("expected %v, received %v", wantErr, err)
+	}
+}
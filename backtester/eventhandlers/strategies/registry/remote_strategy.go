@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/registry/strategyrpc"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+)
+
+const remoteStrategyCallTimeout = 5 * time.Second
+
+// remoteStrategy bridges a strategyrpc.StrategyServiceClient into the
+// module's strategies.Handler interface, so GRPCLoader's callers can treat
+// an out-of-process strategy exactly like a compiled-in one.
+type remoteStrategy struct {
+	name                   string
+	client                 strategyrpc.StrategyServiceClient
+	simultaneousProcessing bool
+}
+
+func newRemoteStrategy(name string, client strategyrpc.StrategyServiceClient) *remoteStrategy {
+	return &remoteStrategy{name: name, client: client}
+}
+
+// Name returns the strategy name this loader resolved the client under.
+func (r *remoteStrategy) Name() string {
+	return r.name
+}
+
+// Description asks the subprocess for its human-readable description.
+func (r *remoteStrategy) Description() string {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteStrategyCallTimeout)
+	defer cancel()
+	resp, err := r.client.Description(ctx, &strategyrpc.Empty{})
+	if err != nil {
+		return ""
+	}
+	return resp.Description
+}
+
+// SetCustomSettings forwards the strategy's custom settings to the
+// subprocess's Init call. Values are stringified since StrategyService
+// carries them as opaque string key/value pairs to stay language-agnostic.
+func (r *remoteStrategy) SetCustomSettings(settings map[string]interface{}) error {
+	stringified := make(map[string]string, len(settings))
+	for k, v := range settings {
+		stringified[k] = fmt.Sprintf("%v", v)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), remoteStrategyCallTimeout)
+	defer cancel()
+	_, err := r.client.Init(ctx, &strategyrpc.InitRequest{CustomSettings: stringified})
+	return err
+}
+
+// SetDefaults is a no-op: default settings are the subprocess's own
+// responsibility, applied before it ever accepts a connection.
+func (r *remoteStrategy) SetDefaults() {}
+
+// SupportsSimultaneousProcessing reports false until the StrategyService
+// protocol grows a batched OnSignal call; today every candle crosses the
+// wire one at a time.
+func (r *remoteStrategy) SupportsSimultaneousProcessing() bool {
+	return false
+}
+
+func (r *remoteStrategy) SetSimultaneousProcessing(on bool) {
+	r.simultaneousProcessing = on
+}
+
+func (r *remoteStrategy) UsingSimultaneousProcessing() bool {
+	return r.simultaneousProcessing
+}
+
+// OnSignal forwards the latest candle to the subprocess and maps its
+// response back into a local order signal.
+func (r *remoteStrategy) OnSignal(d data.Handler, _ portfolio.Handler) (signal.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteStrategyCallTimeout)
+	defer cancel()
+
+	latest := d.Latest()
+	order, err := r.client.OnSignal(ctx, &strategyrpc.Candle{
+		UnixNano: latest.GetTime().UnixNano(),
+		Open:     latest.GetOpenPrice(),
+		High:     latest.GetHighPrice(),
+		Low:      latest.GetLowPrice(),
+		Close:    latest.GetClosePrice(),
+		Volume:   latest.GetVolume(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("strategy service %q: %w", r.name, err)
+	}
+
+	return signal.NewFromDirection(latest, order.Direction, order.Amount), nil
+}
+
+// OnSimultaneousSignals is unsupported: see SupportsSimultaneousProcessing.
+func (r *remoteStrategy) OnSimultaneousSignals(_ []data.Handler, _ portfolio.Handler) ([]signal.Event, error) {
+	return nil, fmt.Errorf("remote strategy %q does not support simultaneous signal processing", r.name)
+}
@@ -0,0 +1,9 @@
+// Package strategyrpc holds the StrategyService protobuf/gRPC contract used
+// by registry.GRPCLoader to run strategies as external subprocesses.
+//
+// strategy.proto is the source of truth; the client/server code that
+// registry.GRPCLoader calls (strategy.pb.go, strategy_grpc.pb.go) is not
+// in this directory and is generated from it with:
+//
+//	protoc --go_out=. --go-grpc_out=. strategy.proto
+package strategyrpc
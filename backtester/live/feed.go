@@ -0,0 +1,60 @@
+package live
+
+import (
+	"fmt"
+
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+// Event is a single websocket-sourced market update. A real Event always
+// has Candle set; trade prints are not forwarded since nothing in the
+// pipeline consumes them - see gctMessageToEvent.
+type Event struct {
+	Candle *gctkline.Candle
+}
+
+// Subscriber is the subset of an exchange's streaming API a Feed needs. Real
+// exchanges satisfy it by forwarding their Websocket connection's kline
+// updates into a single Event channel; see gctexchange.IBotExchange's
+// streaming support.
+type Subscriber interface {
+	// Subscribe starts streaming and returns a channel of Events that is
+	// closed when the underlying connection ends.
+	Subscribe() (<-chan Event, error)
+	// Unsubscribe tears down the streaming connection.
+	Unsubscribe() error
+}
+
+// Feed wraps a Subscriber so BackTest.Run can drain it alongside its own
+// shutdown signal without reaching into the subscription's internals.
+type Feed struct {
+	sub    Subscriber
+	events <-chan Event
+	stop   chan struct{}
+}
+
+// NewFeed subscribes to sub and returns a Feed ready to be drained.
+func NewFeed(sub Subscriber) (*Feed, error) {
+	events, err := sub.Subscribe()
+	if err != nil {
+		return nil, fmt.Errorf("live: subscribing to feed: %w", err)
+	}
+	return &Feed{sub: sub, events: events, stop: make(chan struct{})}, nil
+}
+
+// Next blocks until the next Event arrives, the feed is stopped, or the
+// underlying subscription closes - in the latter two cases ok is false.
+func (f *Feed) Next() (Event, bool) {
+	select {
+	case <-f.stop:
+		return Event{}, false
+	case e, ok := <-f.events:
+		return e, ok
+	}
+}
+
+// Stop unsubscribes from the underlying feed and unblocks any pending Next.
+func (f *Feed) Stop() error {
+	close(f.stop)
+	return f.sub.Unsubscribe()
+}
@@ -0,0 +1,103 @@
+package live
+
+import (
+	"fmt"
+
+	gctexchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+)
+
+// GCTSubscriber adapts a real gctexchange.IBotExchange's websocket
+// connection into a Subscriber, so ModePaper and ModeShadow runs can be
+// driven off an exchange's actual live stream via the module's existing
+// streaming support instead of only a synthetic feed in tests.
+type GCTSubscriber struct {
+	Exchange gctexchange.IBotExchange
+
+	ws     *stream.Websocket
+	events chan Event
+	done   chan struct{}
+}
+
+// NewGCTSubscriber returns a GCTSubscriber wrapping exch's websocket
+// connection. exch must already be loaded with websocket support enabled;
+// NewGCTSubscriber itself does not connect - that happens on Subscribe.
+func NewGCTSubscriber(exch gctexchange.IBotExchange) (*GCTSubscriber, error) {
+	ws, err := exch.GetWebsocket()
+	if err != nil {
+		return nil, fmt.Errorf("live: %s has no websocket support: %w", exch.GetName(), err)
+	}
+	return &GCTSubscriber{Exchange: exch, ws: ws}, nil
+}
+
+// Subscribe connects the exchange's websocket, if it isn't already
+// connected, and starts forwarding its kline updates onto the returned
+// channel until Unsubscribe is called.
+func (s *GCTSubscriber) Subscribe() (<-chan Event, error) {
+	if !s.ws.IsConnected() {
+		if err := s.ws.Connect(); err != nil {
+			return nil, fmt.Errorf("live: connecting %s websocket: %w", s.Exchange.GetName(), err)
+		}
+	}
+
+	s.events = make(chan Event)
+	s.done = make(chan struct{})
+	go s.forward()
+	return s.events, nil
+}
+
+// Unsubscribe stops forwarding, closes the event channel and shuts down
+// the underlying websocket connection.
+func (s *GCTSubscriber) Unsubscribe() error {
+	if s.done != nil {
+		close(s.done)
+	}
+	return s.ws.Shutdown()
+}
+
+// forward translates every message off the exchange's websocket data
+// routine into an Event, dropping message types Feed has no use for (trade
+// prints, order book deltas, account updates, and so on).
+func (s *GCTSubscriber) forward() {
+	defer close(s.events)
+	for {
+		select {
+		case <-s.done:
+			return
+		case msg, ok := <-s.ws.ToRoutine:
+			if !ok {
+				return
+			}
+			e, ok := gctMessageToEvent(msg)
+			if !ok {
+				continue
+			}
+			select {
+			case s.events <- e:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// gctMessageToEvent maps the subset of websocket message types Feed can
+// use into an Event. Everything else - including trade prints, which carry
+// no field the pipeline consumes - is reported as unhandled so forward can
+// drop it rather than forwarding a zero-value Event.
+func gctMessageToEvent(msg interface{}) (Event, bool) {
+	switch v := msg.(type) {
+	case stream.KlineData:
+		return Event{Candle: &gctkline.Candle{
+			Time:   v.Timestamp,
+			Open:   v.OpenPrice,
+			High:   v.HighPrice,
+			Low:    v.LowPrice,
+			Close:  v.ClosePrice,
+			Volume: v.Volume,
+		}}, true
+	default:
+		return Event{}, false
+	}
+}
@@ -0,0 +1,107 @@
+package live
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/models"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+func TestParseMode(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    Mode
+		wantErr bool
+	}{
+		{"", ModeReal, false},
+		{"real", ModeReal, false},
+		{"paper", ModePaper, false},
+		{"shadow", ModeShadow, false},
+		{"bogus", "", true},
+	} {
+		got, err := ParseMode(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseMode(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMatcherMatch(t *testing.T) {
+	m := NewMatcher(models.Build(models.Spec{
+		Fee:      "fixed-bps",
+		Slippage: "vwap",
+		Params:   map[string]float64{"makerBps": 10, "takerBps": 20, "weight": 1},
+	}, nil))
+
+	fill := m.Match(Order{Price: 105, Amount: 2, IsBuy: true}, 110, 90, 0, 0)
+	if fill.ExecutedPrice != 105 {
+		t.Errorf("expected executed price 105, got %v", fill.ExecutedPrice)
+	}
+	if fill.FilledAmount != 2 {
+		t.Errorf("expected full fill, got %v", fill.FilledAmount)
+	}
+	if fill.Fee != 0.42 {
+		t.Errorf("expected fee 0.42, got %v", fill.Fee)
+	}
+}
+
+type fakeSubscriber struct {
+	events chan Event
+}
+
+func (f *fakeSubscriber) Subscribe() (<-chan Event, error) { return f.events, nil }
+func (f *fakeSubscriber) Unsubscribe() error               { return nil }
+
+func TestFeedNext(t *testing.T) {
+	sub := &fakeSubscriber{events: make(chan Event, 1)}
+	feed, err := NewFeed(sub)
+	if err != nil {
+		t.Fatalf("NewFeed returned error: %v", err)
+	}
+	sub.events <- Event{Candle: &gctkline.Candle{Time: time.Unix(100, 0), Close: 100}}
+
+	e, ok := feed.Next()
+	if !ok {
+		t.Fatal("expected ok=true for delivered event")
+	}
+	if e.Candle == nil || e.Candle.Close != 100 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+
+	if err := feed.Stop(); err != nil {
+		t.Errorf("Stop returned error: %v", err)
+	}
+	if _, ok := feed.Next(); ok {
+		t.Error("expected ok=false after Stop")
+	}
+}
+
+func TestSaveAndLoadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	want := State{LastEventUnix: 1234, Portfolio: []byte(`{"holdings":1}`)}
+	if err := SaveState(path, want); err != nil {
+		t.Fatalf("SaveState returned error: %v", err)
+	}
+
+	got, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState returned error: %v", err)
+	}
+	if got.LastEventUnix != want.LastEventUnix {
+		t.Errorf("LastEventUnix = %v, want %v", got.LastEventUnix, want.LastEventUnix)
+	}
+}
+
+func TestLoadStateMissing(t *testing.T) {
+	_, err := LoadState(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
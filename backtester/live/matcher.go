@@ -0,0 +1,55 @@
+package live
+
+import "github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/models"
+
+// Order is the minimal description of an order submitted to Matcher: enough
+// to price and fee a fill without needing the full exchange order type.
+type Order struct {
+	Price   float64
+	Amount  float64
+	IsBuy   bool
+	IsMaker bool
+}
+
+// Fill is the result of matching an Order against the current candle.
+type Fill struct {
+	ExecutedPrice float64
+	FilledAmount  float64
+	Fee           float64
+}
+
+// Matcher fills paper orders against a currency pair's configured models
+// instead of sending them to a real exchange, so ModePaper and ModeShadow
+// never touch the network to place an order.
+type Matcher struct {
+	Models models.Set
+}
+
+// NewMatcher constructs a Matcher from the models.Set registered for the
+// pair being paper-traded.
+func NewMatcher(set models.Set) *Matcher {
+	return &Matcher{Models: set}
+}
+
+// Match fills o against the most recently closed candle's [candleLow,
+// candleHigh] range, applying slippage, size-driven impact, a partial-fill
+// ratio and fees, in that order.
+func (m *Matcher) Match(o Order, candleHigh, candleLow, averageDailyVolume, sigma float64) Fill {
+	executed := m.Models.Slippage.Slip(candleHigh, candleLow, o.Price, o.IsBuy)
+
+	impact := m.Models.Impact.Impact(o.Amount, averageDailyVolume, sigma)
+	if o.IsBuy {
+		executed *= 1 + impact
+	} else {
+		executed *= 1 - impact
+	}
+
+	ratio := m.Models.PartialFill.FillRatio(o.Amount)
+	filled := o.Amount * ratio
+
+	return Fill{
+		ExecutedPrice: executed,
+		FilledAmount:  filled,
+		Fee:           m.Models.Fee.Fee(executed, filled, o.IsMaker),
+	}
+}
@@ -0,0 +1,43 @@
+// Package live adds a websocket-driven live trading mode to the
+// backtester, alongside the existing REST-polled LiveData path. It
+// synthesizes market events from an exchange's streaming connection,
+// matches paper orders against them with the fee/slippage/impact models
+// from the eventhandlers/exchange/models package, and persists enough
+// state to disk that a run can resume after a restart.
+package live
+
+import "fmt"
+
+// Mode controls how orders produced while trading against live market data
+// are handled.
+type Mode string
+
+const (
+	// ModeReal sets up no Feed or Matcher: orders are filled by
+	// Exchange.ExecuteOrder as in a historical run, and none is sent to an
+	// exchange.
+	ModeReal Mode = "real"
+	// ModePaper fills every order against Matcher instead of the real
+	// exchange, so a strategy can be rehearsed against live market data
+	// without risking capital.
+	ModePaper Mode = "paper"
+	// ModeShadow fills every order against Matcher, as ModePaper does, and
+	// also runs it through Exchange.ExecuteOrder. Only the Matcher fill
+	// reaches the portfolio; the other fill is discarded, and no order is
+	// sent to an exchange.
+	ModeShadow Mode = "shadow"
+)
+
+// ParseMode validates s against the known Mode values. An empty string
+// defaults to ModeReal, preserving the behaviour of configs written before
+// Mode existed.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "":
+		return ModeReal, nil
+	case ModeReal, ModePaper, ModeShadow:
+		return Mode(s), nil
+	default:
+		return "", fmt.Errorf("live: unrecognised mode %q", s)
+	}
+}
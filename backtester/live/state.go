@@ -0,0 +1,47 @@
+package live
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// State is the subset of a running BackTest that must survive a restart:
+// enough to resume a paper or shadow run without re-filling events it has
+// already processed or losing track of its holdings.
+type State struct {
+	// LastEventUnix is the Unix timestamp of the last Event this run
+	// processed; Run skips any replayed or re-delivered event at or before
+	// it on resume.
+	LastEventUnix int64 `json:"lastEventUnix"`
+	// Portfolio is a snapshot of BackTest.Portfolio, captured via a plain
+	// JSON marshal of its exported fields - the same approach the
+	// conformance recorder uses for statistics, so no new export surface
+	// is needed on the portfolio package to support resuming.
+	Portfolio json.RawMessage `json:"portfolio"`
+}
+
+// SaveState writes state to path as indented JSON, overwriting any
+// previous snapshot. BackTest.Stop calls this when running live so a
+// restart can pick up where the run left off.
+func SaveState(path string, state State) error {
+	payload, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, payload, 0o600)
+}
+
+// LoadState reads a previously saved State from path. Callers should treat
+// a returned os.IsNotExist error as "no prior run to resume from" rather
+// than a failure.
+func LoadState(path string) (*State, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,201 @@
+package optimizer
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/backtest"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/registry"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+)
+
+// Mode selects how Optimizer explores the parameter space.
+type Mode int
+
+const (
+	// ModeExhaustive runs every combination produced by Grid.
+	ModeExhaustive Mode = iota
+	// ModeRandom runs Samples random draws from Spec.
+	ModeRandom
+	// ModeWalkForward optimizes on a rolling train window and evaluates
+	// the winner on the immediately following, untouched test window.
+	ModeWalkForward
+)
+
+// Result is one parameter set's outcome.
+type Result struct {
+	Parameters  ParameterSet
+	Fold        int // -1 outside of ModeWalkForward
+	IsTestFold  bool
+	Sharpe      float64
+	Sortino     float64
+	MaxDrawdown float64
+	Err         error
+}
+
+// Leaderboard ranks every Result produced by a run, sorted best-Sharpe-first.
+type Leaderboard struct {
+	Results []Result
+	// StabilityScore summarises how consistently the winning parameter set
+	// performed across walk-forward folds: 1.0 means every test fold met or
+	// beat its train fold's Sharpe, 0.0 means none did. It is left at zero
+	// outside of ModeWalkForward.
+	StabilityScore float64
+}
+
+// SortBySharpe orders Results descending by Sharpe ratio, errored runs last.
+func (l *Leaderboard) SortBySharpe() {
+	sort.SliceStable(l.Results, func(i, j int) bool {
+		if (l.Results[i].Err == nil) != (l.Results[j].Err == nil) {
+			return l.Results[i].Err == nil
+		}
+		return l.Results[i].Sharpe > l.Results[j].Sharpe
+	})
+}
+
+// Optimizer sweeps BaseConfig's strategy CustomSettings across Spec,
+// running one BackTest per parameter set in a bounded worker pool.
+type Optimizer struct {
+	BaseConfig *config.Config
+	Spec       Spec
+	Mode       Mode
+
+	// Workers bounds how many BackTest instances run concurrently. It
+	// defaults to 1 if left unset.
+	Workers int
+	// Samples is the number of draws to take in ModeRandom.
+	Samples int
+	// Folds configures the walk-forward window in ModeWalkForward.
+	Folds WalkForwardConfig
+
+	RNG common.RNG
+
+	// ExtraStrategyLoaders are passed through to every BackTest this
+	// Optimizer builds, so a sweep can cover a plugin- or gRPC-loaded
+	// strategy exactly as a single, non-swept run can; see
+	// backtest.RunOptions.ExtraStrategyLoaders.
+	ExtraStrategyLoaders []registry.Loader
+}
+
+// WalkForwardConfig configures the rolling windows ModeWalkForward splits
+// BaseConfig's date range into. See Folds.
+type WalkForwardConfig struct {
+	TrainWindow time.Duration
+	TestWindow  time.Duration
+	StepWindow  time.Duration
+}
+
+// Run executes the configured search and returns a ranked Leaderboard.
+// Bot is the dry-run engine.Engine each BackTest is built against, exactly
+// as it is for a single, non-swept run; Run never places live orders.
+func (o *Optimizer) Run(bot *engine.Engine) (*Leaderboard, error) {
+	switch o.Mode {
+	case ModeRandom:
+		return o.runParameterSets(bot, Sample(o.Spec, o.Samples, o.rng()), -1, false)
+	case ModeWalkForward:
+		return o.runWalkForward(bot)
+	default:
+		return o.runParameterSets(bot, Grid(o.Spec), -1, false)
+	}
+}
+
+func (o *Optimizer) rng() common.RNG {
+	if o.RNG != nil {
+		return o.RNG
+	}
+	return common.NewSeededRNG(0)
+}
+
+// rngForEval returns a fresh RNG for one evaluate call, derived from o's
+// configured seed and i. Each concurrent evaluation in runParameterSets
+// needs its own *rand.Rand - common.SeededRNG.Float64/Intn are not
+// goroutine-safe, so handing every worker the same o.RNG would be a data
+// race the moment two evaluations overlap.
+func (o *Optimizer) rngForEval(i int) common.RNG {
+	var seed int64
+	if s, ok := o.rng().(interface{ Seed() int64 }); ok {
+		seed = s.Seed()
+	}
+	return common.NewSeededRNG(seed + int64(i))
+}
+
+func (o *Optimizer) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return 1
+}
+
+// runParameterSets evaluates every ParameterSet concurrently, bounded by
+// o.workers(), and collects the results into a sorted Leaderboard.
+//
+// Each evaluation builds its BackTest from scratch via backtest.NewFromConfig
+// rather than sharing one instance across goroutines; this is safe for
+// concurrent sweeps precisely because BackTest.Reset fully reinitializes
+// every handler field, so nothing from one run's state can leak into
+// another's even when the underlying *config.Config is shared read-only
+// across the pool.
+func (o *Optimizer) runParameterSets(bot *engine.Engine, sets []ParameterSet, fold int, isTestFold bool) (*Leaderboard, error) {
+	results := make([]Result, len(sets))
+
+	sem := make(chan struct{}, o.workers())
+	var wg sync.WaitGroup
+	for i, set := range sets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, set ParameterSet) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = o.evaluate(bot, set, fold, isTestFold, o.rngForEval(i))
+		}(i, set)
+	}
+	wg.Wait()
+
+	board := &Leaderboard{Results: results}
+	board.SortBySharpe()
+	return board, nil
+}
+
+func (o *Optimizer) evaluate(bot *engine.Engine, set ParameterSet, fold int, isTestFold bool, rng common.RNG) Result {
+	result := Result{Parameters: set, Fold: fold, IsTestFold: isTestFold}
+
+	cfg := applyParameterSet(o.BaseConfig, set)
+	bt, err := backtest.NewFromConfig(cfg, "", "", bot, backtest.RunOptions{
+		RNG:                  rng,
+		ExtraStrategyLoaders: o.ExtraStrategyLoaders,
+	})
+	if err != nil {
+		result.Err = fmt.Errorf("setting up backtest for %v: %w", set, err)
+		return result
+	}
+	defer bt.Reset()
+
+	if err = bt.Run(); err != nil {
+		result.Err = fmt.Errorf("running backtest for %v: %w", set, err)
+		return result
+	}
+
+	result.Sharpe = bt.Statistic.SharpeRatio()
+	result.Sortino = bt.Statistic.SortinoRatio()
+	result.MaxDrawdown = bt.Statistic.MaxDrawdown()
+	return result
+}
+
+// applyParameterSet returns a shallow copy of base with set merged into its
+// strategy CustomSettings, leaving base itself untouched so it can be
+// safely shared read-only across concurrent evaluations.
+func applyParameterSet(base *config.Config, set ParameterSet) *config.Config {
+	cfg := *base
+	cfg.StrategySettings.CustomSettings = make(map[string]interface{}, len(base.StrategySettings.CustomSettings)+len(set))
+	for k, v := range base.StrategySettings.CustomSettings {
+		cfg.StrategySettings.CustomSettings[k] = v
+	}
+	for k, v := range set {
+		cfg.StrategySettings.CustomSettings[k] = v
+	}
+	return &cfg
+}
@@ -0,0 +1,33 @@
+package optimizer
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Report is the combined, per-parameter-set performance summary produced by
+// a run, suitable for writing alongside the regular backtester
+// report.Data output or consuming directly from gen-vector-style tooling.
+type Report struct {
+	Mode           Mode     `json:"mode"`
+	StabilityScore float64  `json:"stabilityScore"`
+	Results        []Result `json:"results"`
+}
+
+// Report builds the combined report for this Leaderboard.
+func (l *Leaderboard) Report(mode Mode) Report {
+	return Report{
+		Mode:           mode,
+		StabilityScore: l.StabilityScore,
+		Results:        l.Results,
+	}
+}
+
+// Save writes the report as indented JSON to path.
+func (r Report) Save(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
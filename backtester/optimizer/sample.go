@@ -0,0 +1,29 @@
+package optimizer
+
+import (
+	"sort"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+)
+
+// Sample draws n random parameter sets from Spec using rng, for a random
+// sampling search instead of an exhaustive grid. Each parameter is drawn
+// uniformly from its [Min, Max] range, ignoring Step.
+func Sample(spec Spec, n int, rng common.RNG) []ParameterSet {
+	keys := make([]string, 0, len(spec.CustomSettings))
+	for k := range spec.CustomSettings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sets := make([]ParameterSet, 0, n)
+	for i := 0; i < n; i++ {
+		set := make(ParameterSet, len(keys))
+		for _, k := range keys {
+			r := spec.CustomSettings[k]
+			set[k] = r.Min + rng.Float64()*(r.Max-r.Min)
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
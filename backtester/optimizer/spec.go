@@ -0,0 +1,67 @@
+// Package optimizer runs many BackTest instances over a parameter grid or
+// distribution and ranks them on risk-adjusted return, so a strategy author
+// can search for good settings instead of guessing them by hand.
+package optimizer
+
+import "sort"
+
+// ParamRange describes the values a single custom strategy setting should
+// be swept across, e.g. {"rsi_period": {"min":10,"max":30,"step":2}}.
+type ParamRange struct {
+	Min  float64 `json:"min" yaml:"min"`
+	Max  float64 `json:"max" yaml:"max"`
+	Step float64 `json:"step" yaml:"step"`
+}
+
+// Values expands the range into the concrete values an exhaustive grid
+// search visits. A zero Step is treated as a single fixed value at Min.
+func (p ParamRange) Values() []float64 {
+	if p.Step <= 0 {
+		return []float64{p.Min}
+	}
+	values := make([]float64, 0, int((p.Max-p.Min)/p.Step)+1)
+	for v := p.Min; v <= p.Max+1e-9; v += p.Step {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Spec describes the parameter space to search. Keys match the strategy's
+// CustomSettings keys; every combination of values is a candidate
+// ParameterSet.
+type Spec struct {
+	CustomSettings map[string]ParamRange `json:"customSettings" yaml:"customSettings"`
+}
+
+// ParameterSet is a single point in the parameter space, ready to be merged
+// into a config.StrategySettings.CustomSettings map.
+type ParameterSet map[string]interface{}
+
+// Grid expands Spec into every combination of its parameter ranges
+// (exhaustive grid search). Keys are visited in sorted order so the output
+// is deterministic regardless of map iteration order.
+func Grid(spec Spec) []ParameterSet {
+	keys := make([]string, 0, len(spec.CustomSettings))
+	for k := range spec.CustomSettings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sets := []ParameterSet{{}}
+	for _, k := range keys {
+		values := spec.CustomSettings[k].Values()
+		expanded := make([]ParameterSet, 0, len(sets)*len(values))
+		for _, existing := range sets {
+			for _, v := range values {
+				next := make(ParameterSet, len(existing)+1)
+				for ek, ev := range existing {
+					next[ek] = ev
+				}
+				next[k] = v
+				expanded = append(expanded, next)
+			}
+		}
+		sets = expanded
+	}
+	return sets
+}
@@ -0,0 +1,49 @@
+package optimizer
+
+import (
+	"testing"
+	"time"
+)
+
+var fixedStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const dayDuration = 24 * time.Hour
+
+func TestGrid(t *testing.T) {
+	t.Parallel()
+	spec := Spec{
+		CustomSettings: map[string]ParamRange{
+			"rsi_period": {Min: 10, Max: 14, Step: 2},
+			"rsi_limit":  {Min: 70, Max: 70, Step: 0},
+		},
+	}
+
+	sets := Grid(spec)
+	if len(sets) != 3 {
+		t.Fatalf("expected 3 parameter sets, received %d", len(sets))
+	}
+	for _, s := range sets {
+		if s["rsi_limit"] != 70.0 {
+			t.Errorf("expected rsi_limit 70, received %v", s["rsi_limit"])
+		}
+	}
+}
+
+func TestFolds(t *testing.T) {
+	t.Parallel()
+	_, err := Folds(fixedStart, fixedStart, dayDuration, dayDuration, 0)
+	if err != ErrInvalidWindow {
+		t.Errorf("expected %v, received %v", ErrInvalidWindow, err)
+	}
+
+	folds, err := Folds(fixedStart, fixedStart.Add(4*dayDuration), dayDuration, dayDuration, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folds) != 2 {
+		t.Fatalf("expected 2 folds, received %d", len(folds))
+	}
+	if !folds[0].TestEnd.Equal(folds[1].TrainStart) {
+		t.Error("expected fold 1's train window to start where fold 0's test window ended")
+	}
+}
@@ -0,0 +1,58 @@
+package optimizer
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidWindow is returned when a walk-forward window configuration
+// cannot produce at least one fold.
+var ErrInvalidWindow = errors.New("optimizer: train/test window longer than the available date range")
+
+// Fold is a single walk-forward train/test window. ParameterSets are
+// optimized against TrainStart..TrainEnd and the winner is evaluated,
+// untouched, against TestStart..TestEnd.
+type Fold struct {
+	Index      int
+	TrainStart time.Time
+	TrainEnd   time.Time
+	TestStart  time.Time
+	TestEnd    time.Time
+}
+
+// Folds splits [start, end) into rolling train/test windows: train covers
+// trainWindow, test covers the testWindow immediately following it, and
+// the whole pair rolls forward by stepWindow each iteration. This keeps
+// every test window strictly out-of-sample relative to the training window
+// that produced the parameters being evaluated on it. A non-positive
+// stepWindow rolls forward by trainWindow+testWindow, so each fold's train
+// window starts where the previous fold's test window ended.
+func Folds(start, end time.Time, trainWindow, testWindow, stepWindow time.Duration) ([]Fold, error) {
+	if stepWindow <= 0 {
+		stepWindow = trainWindow + testWindow
+	}
+
+	var folds []Fold
+	trainStart := start
+	for i := 0; ; i++ {
+		trainEnd := trainStart.Add(trainWindow)
+		testStart := trainEnd
+		testEnd := testStart.Add(testWindow)
+		if testEnd.After(end) {
+			break
+		}
+		folds = append(folds, Fold{
+			Index:      i,
+			TrainStart: trainStart,
+			TrainEnd:   trainEnd,
+			TestStart:  testStart,
+			TestEnd:    testEnd,
+		})
+		trainStart = trainStart.Add(stepWindow)
+	}
+
+	if len(folds) == 0 {
+		return nil, ErrInvalidWindow
+	}
+	return folds, nil
+}
@@ -0,0 +1,95 @@
+package optimizer
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/engine"
+)
+
+// runWalkForward optimizes Spec on each fold's train window, evaluates the
+// winning parameter set on that fold's untouched test window, and rolls
+// forward. The returned Leaderboard's Results interleave train entries
+// (IsTestFold=false, every parameter set) with one test entry per fold
+// (IsTestFold=true, winner only); StabilityScore is the fraction of folds
+// whose test Sharpe met or beat its train Sharpe.
+func (o *Optimizer) runWalkForward(bot *engine.Engine) (*Leaderboard, error) {
+	start, end, err := dateRange(o.BaseConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	folds, err := Folds(start, end, o.Folds.TrainWindow, o.Folds.TestWindow, o.Folds.StepWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Result
+	var stableFolds int
+	for _, fold := range folds {
+		trainCfg := withDateRange(o.BaseConfig, fold.TrainStart, fold.TrainEnd)
+		trainOpt := &Optimizer{
+			BaseConfig:           trainCfg,
+			Spec:                 o.Spec,
+			Mode:                 ModeExhaustive,
+			Workers:              o.Workers,
+			RNG:                  o.RNG,
+			ExtraStrategyLoaders: o.ExtraStrategyLoaders,
+		}
+		trainBoard, err := trainOpt.runParameterSets(bot, Grid(o.Spec), fold.Index, false)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, trainBoard.Results...)
+		if len(trainBoard.Results) == 0 {
+			continue
+		}
+		winner := trainBoard.Results[0]
+
+		testCfg := withDateRange(o.BaseConfig, fold.TestStart, fold.TestEnd)
+		testOpt := &Optimizer{BaseConfig: testCfg, RNG: o.RNG, ExtraStrategyLoaders: o.ExtraStrategyLoaders}
+		testResult := testOpt.evaluate(bot, winner.Parameters, fold.Index, true, testOpt.rngForEval(0))
+		all = append(all, testResult)
+
+		if testResult.Err == nil && winner.Err == nil && testResult.Sharpe >= winner.Sharpe {
+			stableFolds++
+		}
+	}
+
+	board := &Leaderboard{Results: all}
+	board.SortBySharpe()
+	if len(folds) > 0 {
+		board.StabilityScore = float64(stableFolds) / float64(len(folds))
+	}
+	return board, nil
+}
+
+// dateRange reads the date range BaseConfig is currently scoped to, from
+// whichever data source is configured.
+func dateRange(cfg *config.Config) (start, end time.Time, err error) {
+	switch {
+	case cfg.DataSettings.APIData != nil:
+		return cfg.DataSettings.APIData.StartDate, cfg.DataSettings.APIData.EndDate, nil
+	case cfg.DataSettings.DatabaseData != nil:
+		return cfg.DataSettings.DatabaseData.StartDate, cfg.DataSettings.DatabaseData.EndDate, nil
+	default:
+		return time.Time{}, time.Time{}, config.ErrStartEndUnset
+	}
+}
+
+// withDateRange returns a shallow copy of base rescoped to [start, end) on
+// whichever data source it uses.
+func withDateRange(base *config.Config, start, end time.Time) *config.Config {
+	cfg := *base
+	switch {
+	case cfg.DataSettings.APIData != nil:
+		apiData := *cfg.DataSettings.APIData
+		apiData.StartDate, apiData.EndDate = start, end
+		cfg.DataSettings.APIData = &apiData
+	case cfg.DataSettings.DatabaseData != nil:
+		dbData := *cfg.DataSettings.DatabaseData
+		dbData.StartDate, dbData.EndDate = start, end
+		cfg.DataSettings.DatabaseData = &dbData
+	}
+	return &cfg
+}